@@ -0,0 +1,56 @@
+package timecode
+
+// Common audio sample rates
+const (
+	SampleRate_44_1k = 44100
+	SampleRate_48k   = 48000
+	SampleRate_96k   = 96000
+	SampleRate_192k  = 192000
+)
+
+// FrameSample gets the offset of the first audio sample of a frame, at the given sample rate. When frames
+// don't span a whole number of samples, frame boundaries are rounded to the nearest sample. This gives
+// the standard cadences, such as 1602, 1601, 1602, 1601, 1602 samples per frame for 29.97 at 48kHz.
+func (r Rate) FrameSample(frame int64, sampleRate int) int64 {
+	return r.Seconds(frame).Mul(RationalFromInt(int64(sampleRate))).Round()
+}
+
+// SamplesPerFrame gets the number of audio samples in a frame, at the given sample rate
+func (r Rate) SamplesPerFrame(frame int64, sampleRate int) int64 {
+	return r.FrameSample(frame+1, sampleRate) - r.FrameSample(frame, sampleRate)
+}
+
+// SampleCadence gets the repeating sequence of the number of audio samples in each frame, starting at
+// frame zero. Rates where every frame spans a whole number of samples have a cadence of one frame.
+func (r Rate) SampleCadence(sampleRate int) []int64 {
+	perFrame := r.FrameDuration().Mul(RationalFromInt(int64(sampleRate)))
+	_, length, ok := perFrame.Int64s()
+	if !ok {
+		return nil
+	}
+	cadence := make([]int64, length)
+	for i := range cadence {
+		cadence[i] = r.SamplesPerFrame(int64(i), sampleRate)
+	}
+	return cadence
+}
+
+// Samples gets the offset of the first audio sample of this timecode's frame, at the given sample rate
+func (t *Timecode) Samples(sampleRate int) int64 {
+	return t.rate.FrameSample(t.frame, sampleRate)
+}
+
+// FromSamples creates the timecode of the frame that contains an audio sample offset, at the given sample
+// rate. It also returns the offset of the sample within the frame, so that adding it to the timecode's
+// Samples gives back the original sample offset.
+func FromSamples(sample int64, sampleRate int, rate Rate, dropFrame bool) (*Timecode, int64) {
+	// Estimate the frame, and then correct it for the rounding of the frame boundaries
+	frame := rate.Frames(NewRational(sample, int64(sampleRate))).Floor()
+	for rate.FrameSample(frame+1, sampleRate) <= sample {
+		frame++
+	}
+	for rate.FrameSample(frame, sampleRate) > sample {
+		frame--
+	}
+	return FromFrame(frame, rate, dropFrame), sample - rate.FrameSample(frame, sampleRate)
+}
@@ -0,0 +1,63 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRate_SampleCadence(t *testing.T) {
+	require.Equal(t, []int64{1602, 1601, 1602, 1601, 1602}, timecode.Rate_29_97.SampleCadence(timecode.SampleRate_48k))
+	require.Equal(t, []int64{801, 801, 800, 801, 801}, timecode.Rate_59_94.SampleCadence(timecode.SampleRate_48k))
+	require.Equal(t, []int64{2002}, timecode.Rate_23_976.SampleCadence(timecode.SampleRate_48k))
+	require.Equal(t, []int64{1920}, timecode.Rate_25.SampleCadence(timecode.SampleRate_48k))
+	require.Equal(t, []int64{8008}, timecode.Rate_23_976.SampleCadence(timecode.SampleRate_192k))
+	require.Len(t, timecode.Rate_29_97.SampleCadence(timecode.SampleRate_44_1k), 100)
+}
+
+func TestTimecode_Samples(t *testing.T) {
+	type testCase struct {
+		tc         string
+		rate       timecode.Rate
+		sampleRate int
+		samples    int64
+	}
+	cases := []testCase{
+		{"00:00:00:00", timecode.Rate_24, timecode.SampleRate_48k, 0},
+		{"01:00:00:00", timecode.Rate_24, timecode.SampleRate_48k, 172800000},
+		{"01:00:00:00", timecode.Rate_25, timecode.SampleRate_44_1k, 158760000},
+		{"00:00:00;05", timecode.Rate_29_97, timecode.SampleRate_48k, 8008},
+		{"01:00:00;00", timecode.Rate_29_97, timecode.SampleRate_48k, 172799827},
+		{"01:00:00:00", timecode.Rate_23_976, timecode.SampleRate_96k, 345945600},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.samples, timecode.MustParse(tc.tc, tc.rate).Samples(tc.sampleRate), tc.tc)
+	}
+}
+
+func TestFromSamples(t *testing.T) {
+	t.Run("frame boundaries", func(t *testing.T) {
+		tc, remainder := timecode.FromSamples(1601, timecode.SampleRate_48k, timecode.Rate_29_97, true)
+		require.Equal(t, "00:00:00;00", tc.String())
+		require.Equal(t, int64(1601), remainder)
+
+		tc, remainder = timecode.FromSamples(1602, timecode.SampleRate_48k, timecode.Rate_29_97, true)
+		require.Equal(t, "00:00:00;01", tc.String())
+		require.Equal(t, int64(0), remainder)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		rates := []timecode.Rate{timecode.Rate_23_976, timecode.Rate_25, timecode.Rate_29_97, timecode.Rate_59_94}
+		sampleRates := []int{timecode.SampleRate_44_1k, timecode.SampleRate_48k, timecode.SampleRate_96k, timecode.SampleRate_192k}
+		for _, rate := range rates {
+			for _, sampleRate := range sampleRates {
+				for _, sample := range []int64{0, 1, 1601, 1602, 8007, 8008, 172627199, 172627200, 4147200000} {
+					tc, remainder := timecode.FromSamples(sample, sampleRate, rate, false)
+					require.GreaterOrEqual(t, remainder, int64(0))
+					require.Less(t, remainder, rate.SamplesPerFrame(tc.Frame(), sampleRate))
+					require.Equal(t, sample, tc.Samples(sampleRate)+remainder)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,402 @@
+package timecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// bextSize is the size of the fixed part of a bext chunk, before the coding history
+const bextSize = 602
+
+// BextChunk is the broadcast audio extension chunk of a Broadcast WAV (BWF) file
+type BextChunk struct {
+	Description         string
+	Originator          string
+	OriginatorReference string
+	OriginationDate     string
+	OriginationTime     string
+
+	// TimeReference is the number of samples since midnight of the first sample of the file
+	TimeReference uint64
+
+	Version              uint16
+	UMID                 [64]byte
+	LoudnessValue        int16
+	LoudnessRange        int16
+	MaxTruePeakLevel     int16
+	MaxMomentaryLoudness int16
+	MaxShortTermLoudness int16
+	CodingHistory        string
+}
+
+// bextField is a fixed size text field of a bext chunk
+type bextField struct {
+	value  *string
+	offset int
+	size   int
+}
+
+func (b *BextChunk) textFields() []bextField {
+	return []bextField{
+		{&b.Description, 0, 256},
+		{&b.Originator, 256, 32},
+		{&b.OriginatorReference, 288, 32},
+		{&b.OriginationDate, 320, 10},
+		{&b.OriginationTime, 330, 8},
+	}
+}
+
+// ParseBextChunk parses the data of a bext chunk
+func ParseBextChunk(data []byte) (*BextChunk, error) {
+	if len(data) < bextSize {
+		return nil, errors.New("bext chunk is too short")
+	}
+	b := &BextChunk{
+		TimeReference:        uint64(binary.LittleEndian.Uint32(data[338:])) | uint64(binary.LittleEndian.Uint32(data[342:]))<<32,
+		Version:              binary.LittleEndian.Uint16(data[346:]),
+		LoudnessValue:        int16(binary.LittleEndian.Uint16(data[412:])),
+		LoudnessRange:        int16(binary.LittleEndian.Uint16(data[414:])),
+		MaxTruePeakLevel:     int16(binary.LittleEndian.Uint16(data[416:])),
+		MaxMomentaryLoudness: int16(binary.LittleEndian.Uint16(data[418:])),
+		MaxShortTermLoudness: int16(binary.LittleEndian.Uint16(data[420:])),
+		CodingHistory:        bextString(data[bextSize:]),
+	}
+	copy(b.UMID[:], data[348:412])
+	for _, field := range b.textFields() {
+		*field.value = bextString(data[field.offset : field.offset+field.size])
+	}
+	return b, nil
+}
+
+// bextString gets the text of a field, which is padded with zeros
+func bextString(data []byte) string {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+	return string(data)
+}
+
+// MarshalBinary encodes the data of the bext chunk. An error is returned if a text field is too long.
+func (b *BextChunk) MarshalBinary() ([]byte, error) {
+	data := make([]byte, bextSize, bextSize+len(b.CodingHistory))
+	for _, field := range b.textFields() {
+		if len(*field.value) > field.size {
+			return nil, fmt.Errorf("bext field %q is longer than %d bytes", *field.value, field.size)
+		}
+		copy(data[field.offset:], *field.value)
+	}
+	binary.LittleEndian.PutUint32(data[338:], uint32(b.TimeReference))
+	binary.LittleEndian.PutUint32(data[342:], uint32(b.TimeReference>>32))
+	binary.LittleEndian.PutUint16(data[346:], b.Version)
+	copy(data[348:], b.UMID[:])
+	binary.LittleEndian.PutUint16(data[412:], uint16(b.LoudnessValue))
+	binary.LittleEndian.PutUint16(data[414:], uint16(b.LoudnessRange))
+	binary.LittleEndian.PutUint16(data[416:], uint16(b.MaxTruePeakLevel))
+	binary.LittleEndian.PutUint16(data[418:], uint16(b.MaxMomentaryLoudness))
+	binary.LittleEndian.PutUint16(data[420:], uint16(b.MaxShortTermLoudness))
+	return append(data, b.CodingHistory...), nil
+}
+
+// Timecode gets the timecode of the time reference, at the given audio sample rate. It also returns the
+// offset of the time reference within the timecode's frame, in samples.
+func (b *BextChunk) Timecode(sampleRate int, rate Rate, dropFrame bool) (*Timecode, int64) {
+	return FromSamples(int64(b.TimeReference), sampleRate, rate, dropFrame)
+}
+
+// SetTimecode sets the time reference to the first sample of a timecode's frame, at the given audio
+// sample rate
+func (b *BextChunk) SetTimecode(tc *Timecode, sampleRate int) {
+	b.TimeReference = uint64(tc.Samples(sampleRate))
+}
+
+// IXMLSpeed is the SPEED element of an iXML document, which describes the timecode of the recording
+type IXMLSpeed struct {
+	Note                 string `xml:"NOTE,omitempty"`
+	MasterSpeed          string `xml:"MASTER_SPEED,omitempty"`
+	CurrentSpeed         string `xml:"CURRENT_SPEED,omitempty"`
+	TimecodeRate         string `xml:"TIMECODE_RATE,omitempty"`
+	TimecodeFlag         string `xml:"TIMECODE_FLAG,omitempty"`
+	FileSampleRate       string `xml:"FILE_SAMPLE_RATE,omitempty"`
+	AudioBitDepth        string `xml:"AUDIO_BIT_DEPTH,omitempty"`
+	DigitizerSampleRate  string `xml:"DIGITIZER_SAMPLE_RATE,omitempty"`
+	TimestampSamplesHigh string `xml:"TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI,omitempty"`
+	TimestampSamplesLow  string `xml:"TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO,omitempty"`
+	TimestampSampleRate  string `xml:"TIMESTAMP_SAMPLE_RATE,omitempty"`
+}
+
+// IXML is an iXML document from the iXML chunk of a Broadcast WAV file. Only the common elements are
+// kept, so other elements are lost when a parsed document is marshaled again.
+type IXML struct {
+	XMLName xml.Name  `xml:"BWFXML"`
+	Version string    `xml:"IXML_VERSION,omitempty"`
+	Project string    `xml:"PROJECT,omitempty"`
+	Scene   string    `xml:"SCENE,omitempty"`
+	Take    string    `xml:"TAKE,omitempty"`
+	Tape    string    `xml:"TAPE,omitempty"`
+	Note    string    `xml:"NOTE,omitempty"`
+	Speed   IXMLSpeed `xml:"SPEED"`
+}
+
+// ParseIXML parses the data of an iXML chunk
+func ParseIXML(data []byte) (*IXML, error) {
+	var doc IXML
+	if err := xml.Unmarshal(bytes.TrimRight(data, "\x00"), &doc); err != nil {
+		return nil, fmt.Errorf("invalid iXML: %w", err)
+	}
+	return &doc, nil
+}
+
+// MarshalBinary encodes the data of the iXML chunk
+func (x *IXML) MarshalBinary() ([]byte, error) {
+	data, err := xml.MarshalIndent(x, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Rate gets the timecode rate and drop frame flag of the recording
+func (x *IXML) Rate() (Rate, bool, error) {
+	if x.Speed.TimecodeRate == "" {
+		return Rate{}, false, errors.New("iXML has no timecode rate")
+	}
+	r, err := ParseRational(strings.TrimSpace(x.Speed.TimecodeRate))
+	if err != nil {
+		return Rate{}, false, fmt.Errorf("invalid iXML timecode rate: %w", err)
+	}
+	num, den, ok := r.Int64s()
+	if !ok || num > int64(^uint32(0)) || den > int64(^uint32(0)) {
+		return Rate{}, false, fmt.Errorf("invalid iXML timecode rate %s", x.Speed.TimecodeRate)
+	}
+	dropFrame := strings.EqualFold(strings.TrimSpace(x.Speed.TimecodeFlag), "DF")
+	rate, err := RateFromFraction(int(num), int(den))
+
+	// Decimal rates (ie. 29.97) are approximations, so snap them to the rate they're close to
+	if err == nil && (strings.Contains(x.Speed.TimecodeRate, ".") || dropFrame && !rate.IsDropFrameCapable()) {
+		rate, err = measuredRate(r.Float64(), 0.0005)
+	}
+	if err != nil {
+		return Rate{}, false, err
+	}
+	if dropFrame && !rate.IsDropFrameCapable() {
+		return Rate{}, false, fmt.Errorf("rate %s can't be drop frame", rate.Str)
+	}
+	return rate, dropFrame, nil
+}
+
+// SetRate sets the timecode rate and drop frame flag of the recording
+func (x *IXML) SetRate(rate Rate, dropFrame bool) {
+	x.Speed.TimecodeRate = fmt.Sprintf("%d/%d", rate.Num, rate.Den)
+	x.Speed.TimecodeFlag = "NDF"
+	if dropFrame {
+		x.Speed.TimecodeFlag = "DF"
+	}
+}
+
+// TimeReference gets the number of samples since midnight of the first sample, and the sample rate they're
+// counted at, if the document has them
+func (x *IXML) TimeReference() (uint64, int, bool) {
+	high, err1 := strconv.ParseUint(strings.TrimSpace(x.Speed.TimestampSamplesHigh), 10, 32)
+	low, err2 := strconv.ParseUint(strings.TrimSpace(x.Speed.TimestampSamplesLow), 10, 32)
+	sampleRate, err3 := strconv.Atoi(strings.TrimSpace(x.Speed.TimestampSampleRate))
+	if err1 != nil || err2 != nil || err3 != nil || sampleRate <= 0 {
+		return 0, 0, false
+	}
+	return high<<32 | low, sampleRate, true
+}
+
+// SetTimeReference sets the number of samples since midnight of the first sample, and the sample rate they're
+// counted at
+func (x *IXML) SetTimeReference(samples uint64, sampleRate int) {
+	x.Speed.TimestampSamplesHigh = strconv.FormatUint(samples>>32, 10)
+	x.Speed.TimestampSamplesLow = strconv.FormatUint(samples&0xffffffff, 10)
+	x.Speed.TimestampSampleRate = strconv.Itoa(sampleRate)
+}
+
+// RIFFChunk is a chunk of a RIFF file. The data of large chunks, such as the audio data, is skipped, in
+// which case Data is nil and only the size is known.
+type RIFFChunk struct {
+	ID   string
+	Size int64
+	Data []byte
+}
+
+// maxRIFFChunkData is the largest chunk whose data is read by ReadRIFFChunks
+const maxRIFFChunkData = 16 << 20
+
+// ReadRIFFChunks reads the chunks of a RIFF or RF64 WAVE file. The audio data isn't read, and is skipped
+// by seeking if r is an io.Seeker.
+func ReadRIFFChunks(r io.Reader) ([]RIFFChunk, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, errors.New("RIFF header is too short")
+	}
+	form := string(header[:4])
+	if form != "RIFF" && form != "RF64" || string(header[8:]) != "WAVE" {
+		return nil, errors.New("not a WAVE file")
+	}
+
+	var chunks []RIFFChunk
+	var dataSize int64 = -1
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err == io.EOF {
+			return chunks, nil
+		} else if err != nil {
+			return nil, errors.New("truncated RIFF chunk header")
+		}
+		chunk := RIFFChunk{
+			ID:   string(chunkHeader[:4]),
+			Size: int64(binary.LittleEndian.Uint32(chunkHeader[4:])),
+		}
+
+		// RF64 files give the size of the audio data in the ds64 chunk
+		if chunk.ID == "data" && chunk.Size == 0xffffffff && dataSize >= 0 {
+			chunk.Size = dataSize
+		}
+
+		// Read the data of small chunks, and skip the others. Chunks are padded to an even size.
+		padded := chunk.Size + chunk.Size%2
+		if chunk.ID != "data" && chunk.Size <= maxRIFFChunkData {
+			chunk.Data = make([]byte, chunk.Size)
+			if _, err := io.ReadFull(r, chunk.Data); err != nil {
+				return nil, fmt.Errorf("truncated %q chunk", chunk.ID)
+			}
+			if err := skip(r, padded-chunk.Size); err != nil && err != io.EOF {
+				return nil, err
+			}
+		} else if err := skip(r, padded); err != nil {
+			// The audio data is often the last chunk, so a missing pad byte is tolerated
+			if err != io.EOF || chunk.ID != "data" {
+				return nil, fmt.Errorf("truncated %q chunk", chunk.ID)
+			}
+		}
+		if chunk.ID == "ds64" && len(chunk.Data) >= 16 {
+			dataSize = int64(binary.LittleEndian.Uint64(chunk.Data[8:]))
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+// skip skips n bytes of a reader, seeking if it can. It returns io.EOF if the reader ends first.
+func skip(r io.Reader, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	if seeker, ok := r.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		if _, err := seeker.Seek(min64(current+n, end), io.SeekStart); err != nil {
+			return err
+		}
+		if current+n > end {
+			return io.EOF
+		}
+		return nil
+	}
+	if skipped, err := io.CopyN(io.Discard, r, n); skipped < n {
+		if err == nil {
+			err = io.EOF
+		}
+		return err
+	}
+	return nil
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// WriteRIFFChunk writes a chunk of a RIFF file, padded to an even size
+func WriteRIFFChunk(w io.Writer, id string, data []byte) error {
+	if len(id) != 4 {
+		return fmt.Errorf("invalid RIFF chunk ID %q", id)
+	}
+	if int64(len(data)) > 0xffffffff {
+		return errors.New("RIFF chunk is too large")
+	}
+	header := make([]byte, 8)
+	copy(header, id)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(data)))
+	if len(data)%2 == 1 {
+		data = append(data[:len(data):len(data)], 0)
+	}
+	_, err := w.Write(append(header, data...))
+	return err
+}
+
+// BWF is the timecode metadata of a Broadcast WAV file
+type BWF struct {
+	SampleRate int
+	Bext       *BextChunk
+	IXML       *IXML
+}
+
+// ReadBWF reads the timecode metadata of a Broadcast WAV file. The bext and iXML chunks are nil if they're
+// missing from the file.
+func ReadBWF(r io.Reader) (*BWF, error) {
+	chunks, err := ReadRIFFChunks(r)
+	if err != nil {
+		return nil, err
+	}
+	var bwf BWF
+	for _, chunk := range chunks {
+		switch chunk.ID {
+		case "fmt ":
+			if len(chunk.Data) < 8 {
+				return nil, errors.New("fmt chunk is too short")
+			}
+			bwf.SampleRate = int(binary.LittleEndian.Uint32(chunk.Data[4:]))
+		case "bext":
+			if bwf.Bext, err = ParseBextChunk(chunk.Data); err != nil {
+				return nil, err
+			}
+		case "iXML":
+			if bwf.IXML, err = ParseIXML(chunk.Data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if bwf.SampleRate <= 0 {
+		return nil, errors.New("WAVE file has no sample rate")
+	}
+	return &bwf, nil
+}
+
+// Timecode gets the start timecode of the recording, from the time reference of the bext chunk, or else
+// from the iXML timestamp. The timecode rate comes from the iXML chunk. It also returns the offset of the
+// first sample within the timecode's frame, in samples.
+func (b *BWF) Timecode() (*Timecode, int64, error) {
+	if b.IXML == nil {
+		return nil, 0, errors.New("BWF has no iXML timecode rate")
+	}
+	rate, dropFrame, err := b.IXML.Rate()
+	if err != nil {
+		return nil, 0, err
+	}
+	if b.Bext != nil {
+		tc, offset := b.Bext.Timecode(b.SampleRate, rate, dropFrame)
+		return tc, offset, nil
+	}
+	samples, sampleRate, ok := b.IXML.TimeReference()
+	if !ok {
+		return nil, 0, errors.New("BWF has no time reference")
+	}
+	tc, offset := FromSamples(int64(samples), sampleRate, rate, dropFrame)
+	return tc, offset, nil
+}
@@ -0,0 +1,221 @@
+package timecode_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+const testIXML = `<?xml version="1.0" encoding="UTF-8"?>
+<BWFXML>
+	<IXML_VERSION>2.10</IXML_VERSION>
+	<PROJECT>Feature</PROJECT>
+	<SCENE>12A</SCENE>
+	<TAKE>3</TAKE>
+	<SPEED>
+		<MASTER_SPEED>24000/1001</MASTER_SPEED>
+		<TIMECODE_RATE>24000/1001</TIMECODE_RATE>
+		<TIMECODE_FLAG>NDF</TIMECODE_FLAG>
+		<FILE_SAMPLE_RATE>48000</FILE_SAMPLE_RATE>
+		<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>0</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>
+		<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>1729728000</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>
+		<TIMESTAMP_SAMPLE_RATE>48000</TIMESTAMP_SAMPLE_RATE>
+	</SPEED>
+	<USER>unknown elements are ignored</USER>
+</BWFXML>
+`
+
+// wavFile creates a WAVE file with the given chunks after the fmt chunk, and an odd amount of audio data
+func wavFile(t *testing.T, sampleRate int, chunks map[string][]byte) []byte {
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	format := make([]byte, 16)
+	binary.LittleEndian.PutUint16(format, 1)
+	binary.LittleEndian.PutUint16(format[2:], 1)
+	binary.LittleEndian.PutUint32(format[4:], uint32(sampleRate))
+	require.NoError(t, timecode.WriteRIFFChunk(&body, "fmt ", format))
+	for _, id := range []string{"bext", "iXML"} {
+		if data, ok := chunks[id]; ok {
+			require.NoError(t, timecode.WriteRIFFChunk(&body, id, data))
+		}
+	}
+	require.NoError(t, timecode.WriteRIFFChunk(&body, "data", make([]byte, 1001)))
+
+	header := []byte("RIFF\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint32(header[4:], uint32(body.Len()))
+	return append(header, body.Bytes()...)
+}
+
+func TestBextChunk(t *testing.T) {
+	bext := &timecode.BextChunk{
+		Description:     "Scene 12A take 3",
+		Originator:      "Recorder",
+		OriginationDate: "2024-03-08",
+		OriginationTime: "10:00:00",
+		Version:         2,
+		LoudnessValue:   -2300,
+		CodingHistory:   "A=PCM,F=48000,W=24,M=mono\r\n",
+	}
+	bext.SetTimecode(timecode.MustParse("01:00:00:00", timecode.Rate_25), timecode.SampleRate_48k)
+	require.Equal(t, uint64(172800000), bext.TimeReference)
+
+	data, err := bext.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, 602+len(bext.CodingHistory))
+	require.Equal(t, []byte{0x00, 0xb8, 0x4c, 0x0a, 0x00, 0x00, 0x00, 0x00}, data[338:346])
+	parsed, err := timecode.ParseBextChunk(data)
+	require.NoError(t, err)
+	require.Equal(t, bext, parsed)
+
+	tc, offset := parsed.Timecode(timecode.SampleRate_48k, timecode.Rate_25, false)
+	require.Equal(t, "01:00:00:00", tc.String())
+	require.Equal(t, int64(0), offset)
+
+	// Time references beyond 32 bits, and within a frame
+	bext.TimeReference = 1<<32 + 1000
+	data, err = bext.MarshalBinary()
+	require.NoError(t, err)
+	parsed, err = timecode.ParseBextChunk(data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1<<32+1000), parsed.TimeReference)
+	tc, offset = parsed.Timecode(timecode.SampleRate_48k, timecode.Rate_29_97, true)
+	require.Equal(t, "24:51:18;17", tc.String())
+	require.Equal(t, tc.Samples(timecode.SampleRate_48k)+offset, int64(1<<32+1000))
+
+	_, err = timecode.ParseBextChunk(data[:601])
+	require.Error(t, err)
+	bext.OriginationDate = "2024-03-08T10:00"
+	_, err = bext.MarshalBinary()
+	require.Error(t, err)
+}
+
+func TestIXML(t *testing.T) {
+	doc, err := timecode.ParseIXML([]byte(testIXML + "\x00\x00"))
+	require.NoError(t, err)
+	require.Equal(t, "12A", doc.Scene)
+	rate, dropFrame, err := doc.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_23_976, rate)
+	require.False(t, dropFrame)
+	samples, sampleRate, ok := doc.TimeReference()
+	require.True(t, ok)
+	require.Equal(t, uint64(1729728000), samples)
+	require.Equal(t, 48000, sampleRate)
+
+	// Round trip the document, with a new rate and time reference
+	doc.SetRate(timecode.Rate_29_97, true)
+	doc.SetTimeReference(1<<32+5, 96000)
+	data, err := doc.MarshalBinary()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "<TIMECODE_RATE>30000/1001</TIMECODE_RATE>")
+	doc, err = timecode.ParseIXML(data)
+	require.NoError(t, err)
+	require.Equal(t, "Feature", doc.Project)
+	rate, dropFrame, err = doc.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, rate)
+	require.True(t, dropFrame)
+	samples, sampleRate, ok = doc.TimeReference()
+	require.True(t, ok)
+	require.Equal(t, uint64(1<<32+5), samples)
+	require.Equal(t, 96000, sampleRate)
+
+	// Decimal rates are snapped to the rate they're close to
+	for str, expected := range map[string]timecode.Rate{
+		"29.97":  timecode.Rate_29_97,
+		"23.976": timecode.Rate_23_976,
+		"25.0":   timecode.Rate_25,
+	} {
+		rate, dropFrame, err = (&timecode.IXML{Speed: timecode.IXMLSpeed{TimecodeRate: str, TimecodeFlag: "DF"}}).Rate()
+		if expected.IsDropFrameCapable() {
+			require.NoError(t, err, str)
+			require.True(t, expected.Equal(rate), str)
+			require.True(t, dropFrame, str)
+		} else {
+			require.Error(t, err, str)
+		}
+		rate, _, err = (&timecode.IXML{Speed: timecode.IXMLSpeed{TimecodeRate: str}}).Rate()
+		require.NoError(t, err, str)
+		require.True(t, expected.Equal(rate), str)
+	}
+
+	for _, speed := range []timecode.IXMLSpeed{
+		{},
+		{TimecodeRate: "fast"},
+		{TimecodeRate: "25", TimecodeFlag: "DF"},
+		{TimecodeRate: "0/1"},
+	} {
+		_, _, err := (&timecode.IXML{Speed: speed}).Rate()
+		require.Error(t, err)
+	}
+	_, _, ok = (&timecode.IXML{}).TimeReference()
+	require.False(t, ok)
+	_, err = timecode.ParseIXML([]byte("<BWFXML><SPEED>"))
+	require.Error(t, err)
+}
+
+func TestReadBWF(t *testing.T) {
+	bext := &timecode.BextChunk{Description: "odd"}
+	bext.SetTimecode(timecode.MustParse("10:00:00:00", timecode.Rate_23_976), timecode.SampleRate_48k)
+	bextData, err := bext.MarshalBinary()
+	require.NoError(t, err)
+
+	file := wavFile(t, timecode.SampleRate_48k, map[string][]byte{"bext": bextData, "iXML": []byte(testIXML)})
+	bwf, err := timecode.ReadBWF(bytes.NewReader(file))
+	require.NoError(t, err)
+	require.Equal(t, timecode.SampleRate_48k, bwf.SampleRate)
+	require.Equal(t, bext, bwf.Bext)
+	tc, offset, err := bwf.Timecode()
+	require.NoError(t, err)
+	require.Equal(t, "10:00:00:00", tc.String())
+	require.Equal(t, timecode.Rate_23_976, tc.Rate())
+	require.Equal(t, int64(0), offset)
+
+	// Without seeking, and using the iXML time reference
+	file = wavFile(t, timecode.SampleRate_48k, map[string][]byte{"iXML": []byte(testIXML)})
+	bwf, err = timecode.ReadBWF(io.MultiReader(bytes.NewReader(file)))
+	require.NoError(t, err)
+	require.Nil(t, bwf.Bext)
+	tc, _, err = bwf.Timecode()
+	require.NoError(t, err)
+	require.Equal(t, "10:00:00:00", tc.String())
+
+	// Without iXML, there's no timecode rate
+	file = wavFile(t, timecode.SampleRate_48k, map[string][]byte{"bext": bextData})
+	bwf, err = timecode.ReadBWF(bytes.NewReader(file))
+	require.NoError(t, err)
+	_, _, err = bwf.Timecode()
+	require.Error(t, err)
+
+	for _, data := range [][]byte{
+		[]byte("RIFF"),
+		append([]byte("RIFF\x00\x00\x00\x00AVI "), file[12:]...),
+		file[:40],
+		file[:12],
+	} {
+		_, err := timecode.ReadBWF(bytes.NewReader(data))
+		require.Error(t, err)
+	}
+}
+
+func TestReadRIFFChunks_RF64(t *testing.T) {
+	ds64 := make([]byte, 28)
+	binary.LittleEndian.PutUint64(ds64[8:], 6)
+	var file bytes.Buffer
+	file.WriteString("RF64\xff\xff\xff\xffWAVE")
+	require.NoError(t, timecode.WriteRIFFChunk(&file, "ds64", ds64))
+	file.WriteString("data\xff\xff\xff\xff\x00\x00\x00\x00\x00\x00")
+	require.NoError(t, timecode.WriteRIFFChunk(&file, "iXML", []byte("<BWFXML/>")))
+
+	chunks, err := timecode.ReadRIFFChunks(&file)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	require.Equal(t, int64(6), chunks[1].Size)
+	require.Nil(t, chunks[1].Data)
+	require.Equal(t, "iXML", chunks[2].ID)
+	require.Equal(t, []byte("<BWFXML/>"), chunks[2].Data)
+}
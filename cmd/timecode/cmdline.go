@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/spiretechnology/go-timecode"
+)
+
+// cmdline parses the command line of a command. Flags can appear anywhere among the operands.
+type cmdline struct {
+	fs       *flag.FlagSet
+	json     bool
+	defaults map[string]string
+}
+
+// newCmdline creates the command line of a command, with defaults for any of its flags that aren't given
+func newCmdline(name string, defaults map[string]string) *cmdline {
+	c := &cmdline{
+		fs:       flag.NewFlagSet(name, flag.ContinueOnError),
+		defaults: defaults,
+	}
+	c.fs.SetOutput(io.Discard)
+	c.fs.BoolVar(&c.json, "json", false, "print the result as JSON")
+	return c
+}
+
+// parse parses the flags, and returns the operands. Arguments that start with a dash followed by a
+// digit are operands (ie. "-00:00:10:00"), not flags.
+func (c *cmdline) parse(args []string) ([]string, error) {
+	for name, value := range c.defaults {
+		if f := c.fs.Lookup(name); f != nil {
+			if err := f.Value.Set(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var flags, operands []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			operands = append(operands, args[i+1:]...)
+			i = len(args)
+		case isOperand(arg):
+			operands = append(operands, arg)
+		default:
+			// Flags that aren't booleans take the next argument as their value, unless it's given with =
+			flags = append(flags, arg)
+			name := strings.TrimLeft(arg, "-")
+			if !strings.Contains(name, "=") && !c.isBoolFlag(name) && i+1 < len(args) {
+				i++
+				flags = append(flags, args[i])
+			}
+		}
+	}
+	if err := c.fs.Parse(flags); err != nil {
+		return nil, err
+	}
+	return operands, nil
+}
+
+// isBoolFlag checks if the named flag is a boolean flag
+func (c *cmdline) isBoolFlag(name string) bool {
+	f := c.fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// isOperand checks if an argument is an operand rather than a flag
+func isOperand(arg string) bool {
+	return len(arg) < 2 || arg[0] != '-' || (arg[1] >= '0' && arg[1] <= '9')
+}
+
+// splitArgs splits a line into arguments the way a shell would. Arguments are separated by whitespace,
+// and can be quoted with single or double quotes. Outside of single quotes, a backslash escapes the next
+// character.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// stringsFlag is a flag that can be repeated
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// result is the result of a command
+type result struct {
+	Input     string  `json:"input,omitempty"`
+	Kind      string  `json:"kind,omitempty"`
+	Timecode  string  `json:"timecode"`
+	Frames    int64   `json:"frames"`
+	Rate      string  `json:"rate"`
+	DropFrame bool    `json:"dropFrame"`
+	Seconds   float64 `json:"seconds"`
+
+	// number is the result of an expression that evaluates to a number, which has no timecode
+	number string
+
+	// text is printed instead of the timecode when not printing JSON
+	text string
+}
+
+// newResult creates the result for a timecode, which can be negative. Negative timecodes are formatted as
+// their magnitude with a minus sign, including any sub-frames, and their frames are counted towards zero
+// to match.
+func newResult(tc *timecode.Timecode) *result {
+	rate := tc.Rate()
+	str, frames := tc.String(), tc.Frame()
+	if frames < 0 {
+		magnitude := timecode.FromFrame(0, rate, tc.DropFrame()).Sub(tc)
+		str, frames = "-"+magnitude.String(), -magnitude.Frame()
+	}
+	return &result{
+		Timecode:  str,
+		Frames:    frames,
+		Rate:      rate.String(),
+		DropFrame: tc.DropFrame(),
+		Seconds:   tc.Seconds().Float64(),
+	}
+}
+
+// MarshalJSON marshals the result, leaving out the timecode fields if the result is a number or a
+// duration without a rate
+func (r *result) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.number != "":
+		return json.Marshal(struct {
+			Input  string `json:"input,omitempty"`
+			Kind   string `json:"kind"`
+			Number string `json:"number"`
+		}{r.Input, r.Kind, r.number})
+	case r.Timecode == "":
+		return json.Marshal(struct {
+			Input   string  `json:"input,omitempty"`
+			Kind    string  `json:"kind"`
+			Seconds float64 `json:"seconds"`
+		}{r.Input, r.Kind, r.Seconds})
+	}
+	type fields result
+	return json.Marshal((*fields)(r))
+}
+
+// print prints the result, either as text or as a line of JSON
+func (r *result) print(w io.Writer, asJSON bool) error {
+	if asJSON {
+		out, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+	text := r.text
+	if text == "" {
+		text = r.Timecode
+	}
+	_, err := fmt.Fprintln(w, text)
+	return err
+}
@@ -0,0 +1,356 @@
+// Command timecode does timecode math and conversion from the command line.
+//
+//	timecode add 01:00:00;00 +00:00:10;00 --rate 29.97
+//	timecode frames 01:00:00:00 --rate 24
+//	timecode convert 01:00:00:00 --from 23.976 --to 25
+//	timecode diff 01:00:00:00 01:00:10:00 --rate 24
+//	timecode eval "01:00:00:00 + 90f - 2s" --rate 24
+//	timecode batch --json < commands.txt
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spiretechnology/go-timecode"
+)
+
+const usage = `usage: timecode <command> [arguments] [--json]
+
+commands:
+  add <timecode> [+|-]<timecode|frames>... --rate <rate>
+        add and subtract timecodes and frame counts
+  frames <timecode> --rate <rate>
+        print the frame count of a timecode
+  convert <timecode> --from <rate> --to <rate> [--mode time|frames|label]
+        convert a timecode to another rate, keeping its time, frame count or label
+  diff <from> <to> --rate <rate>
+        print the duration between two timecodes
+  eval <expression> [--rate <rate>] [--var <name>=<expression>]...
+        evaluate a timecode expression (ie. "(tc2 - tc1) * 2")
+  batch [--rate <rate>]
+        run one command per line from stdin, with arguments quoted like in a shell
+
+Rates can have a drop frame hint (ie. 29.97df) to choose the drop frame mode of the result.
+`
+
+// command runs a command with the operands and flags on its command line
+type command func(c *cmdline, args []string) (*result, error)
+
+var commands = map[string]command{
+	"add":     runAdd,
+	"frames":  runFrames,
+	"convert": runConvert,
+	"diff":    runDiff,
+	"eval":    runEval,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command line, and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	case "batch":
+		return runBatch(args[1:], stdin, stdout, stderr)
+	}
+
+	res, asJSON, err := runCommand(args, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "timecode: %v\n", err)
+		return 1
+	}
+	if err := res.print(stdout, asJSON); err != nil {
+		fmt.Fprintf(stderr, "timecode: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runCommand runs a single command, with defaults for any of its flags that aren't given. It also
+// reports whether the result should be printed as JSON.
+func runCommand(args []string, defaults map[string]string) (*result, bool, error) {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown command %q", args[0])
+	}
+	c := newCmdline(args[0], defaults)
+	res, err := cmd(c, args[1:])
+	return res, c.json, err
+}
+
+// runBatch runs one command per line from stdin. Blank lines and lines starting with # are skipped. Errors
+// are reported for each line, and the exit code is non-zero if any line failed.
+func runBatch(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := newCmdline("batch", nil)
+	rate := c.fs.String("rate", "", "default rate for commands that don't specify one")
+	if _, err := c.parse(args); err != nil {
+		fmt.Fprintf(stderr, "timecode: %v\n", err)
+		return 2
+	}
+	defaults := map[string]string{}
+	if *rate != "" {
+		defaults["rate"] = *rate
+	}
+
+	code := 0
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Run the command on the line, and print its result. Arguments can be quoted like in a shell.
+		var res *result
+		asJSON := c.json
+		args, err := splitArgs(line)
+		if err == nil {
+			res, asJSON, err = runCommand(args, defaults)
+			asJSON = asJSON || c.json
+		}
+		if err == nil {
+			res.Input = line
+			err = res.print(stdout, asJSON)
+		}
+		if err != nil {
+			code = 1
+			if asJSON {
+				out, _ := json.Marshal(struct {
+					Input string `json:"input"`
+					Error string `json:"error"`
+				}{line, err.Error()})
+				fmt.Fprintln(stdout, string(out))
+			} else {
+				fmt.Fprintf(stderr, "timecode: %s: %v\n", line, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "timecode: %v\n", err)
+		return 1
+	}
+	return code
+}
+
+// runAdd adds and subtracts timecodes and frame counts. The result uses the drop frame mode of the
+// first timecode.
+func runAdd(c *cmdline, args []string) (*result, error) {
+	rateStr := c.fs.String("rate", "", "frame rate of the timecodes")
+	operands, err := c.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) == 0 {
+		return nil, errors.New("add needs at least one timecode")
+	}
+	rate, hints, err := parseRate("rate", *rateStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var sum *timecode.Timecode
+	for _, operand := range operands {
+		tc, negative, err := parseOperand(operand, rate, hints.DropFrame)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case sum == nil && negative:
+			sum = timecode.FromFrame(0, rate, tc.DropFrame()).Sub(tc)
+		case sum == nil:
+			sum = tc
+		case negative:
+			sum = sum.Sub(tc)
+		default:
+			sum = sum.Add(tc)
+		}
+	}
+	return newResult(sum), nil
+}
+
+// runFrames gets the frame count of a timecode
+func runFrames(c *cmdline, args []string) (*result, error) {
+	rateStr := c.fs.String("rate", "", "frame rate of the timecode")
+	operands, err := c.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) != 1 {
+		return nil, errors.New("frames needs exactly one timecode")
+	}
+	rate, _, err := parseRate("rate", *rateStr)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := timecode.Parse(operands[0], rate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operands[0], err)
+	}
+	res := newResult(tc)
+	res.text = strconv.FormatInt(tc.Frame(), 10)
+	return res, nil
+}
+
+// runConvert converts a timecode to another rate. The result is drop frame if the target rate has a drop
+// frame hint, or if the timecode is drop frame and the target rate supports it.
+func runConvert(c *cmdline, args []string) (*result, error) {
+	fromStr := c.fs.String("from", "", "frame rate of the timecode")
+	toStr := c.fs.String("to", "", "frame rate to convert to")
+	mode := c.fs.String("mode", "time", "what to keep: time, frames or label")
+	operands, err := c.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) != 1 {
+		return nil, errors.New("convert needs exactly one timecode")
+	}
+	from, _, err := parseRate("from", *fromStr)
+	if err != nil {
+		return nil, err
+	}
+	to, hints, err := parseRate("to", *toStr)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := timecode.Parse(operands[0], from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operands[0], err)
+	}
+
+	// Choose the drop frame mode of the result
+	dropFrame := tc.DropFrame() && to.IsDropFrameCapable()
+	if hints.DropFrame || hints.NonDropFrame {
+		dropFrame = hints.DropFrame
+	}
+
+	switch *mode {
+	case "time":
+		return newResult(tc.ConvertRate(to, dropFrame)), nil
+	case "frames":
+		return newResult(timecode.FromFrame(tc.Frame(), to, dropFrame)), nil
+	case "label":
+		components := tc.Components()
+		if components.Frames >= int64(to.Nominal) {
+			return nil, fmt.Errorf("%s has no equivalent at %s", operands[0], to.Str)
+		}
+		return newResult(timecode.FromComponents(components, to, dropFrame)), nil
+	default:
+		return nil, fmt.Errorf("invalid mode %q", *mode)
+	}
+}
+
+// runDiff gets the duration from one timecode to another, which is negative if the second timecode is
+// earlier
+func runDiff(c *cmdline, args []string) (*result, error) {
+	rateStr := c.fs.String("rate", "", "frame rate of the timecodes")
+	operands, err := c.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) != 2 {
+		return nil, errors.New("diff needs exactly two timecodes")
+	}
+	rate, _, err := parseRate("rate", *rateStr)
+	if err != nil {
+		return nil, err
+	}
+	var tcs [2]*timecode.Timecode
+	for i, operand := range operands {
+		if tcs[i], err = timecode.Parse(operand, rate); err != nil {
+			return nil, fmt.Errorf("%s: %w", operand, err)
+		}
+	}
+	return newResult(tcs[1].Sub(tcs[0])), nil
+}
+
+// runEval evaluates a timecode expression. The operands are joined into a single expression, so that it
+// doesn't need to be quoted as long as the shell leaves it alone. Variables are evaluated in order, so
+// they can refer to the variables before them.
+func runEval(c *cmdline, args []string) (*result, error) {
+	rateStr := c.fs.String("rate", "", "frame rate of timecodes and frame counts without a rate annotation")
+	var vars stringsFlag
+	c.fs.Var(&vars, "var", "variable as name=expression")
+	operands, err := c.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(operands) == 0 {
+		return nil, errors.New("eval needs an expression")
+	}
+
+	env := timecode.ExpressionEnv{Vars: map[string]timecode.Value{}}
+	if *rateStr != "" {
+		rate, hints, err := parseRate("rate", *rateStr)
+		if err != nil {
+			return nil, err
+		}
+		env.Rate, env.DropFrame = rate, hints.DropFrame
+	}
+	for _, v := range vars {
+		name, expr, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("--var %s: expected name=expression", v)
+		}
+		if env.Vars[name], err = timecode.Evaluate(expr, env); err != nil {
+			return nil, fmt.Errorf("--var %s: %w", v, err)
+		}
+	}
+
+	value, err := timecode.Evaluate(strings.Join(operands, " "), env)
+	if err != nil {
+		return nil, err
+	}
+	tc := value.Timecode()
+	switch {
+	case value.Kind() == timecode.KindNumber:
+		return &result{Kind: value.Kind().String(), number: value.String(), text: value.String()}, nil
+	case tc == nil:
+		// Durations in seconds that were evaluated without a rate can't be shown as a timecode
+		return &result{Kind: value.Kind().String(), Seconds: value.Number().Float64(), text: value.String()}, nil
+	}
+	res := newResult(tc)
+	res.Kind = value.Kind().String()
+	return res, nil
+}
+
+// parseRate parses the value of a rate flag
+func parseRate(name, str string) (timecode.Rate, timecode.RateHints, error) {
+	if str == "" {
+		return timecode.Rate{}, timecode.RateHints{}, fmt.Errorf("--%s is required", name)
+	}
+	rate, hints, err := timecode.ParseRateHints(str)
+	if err != nil {
+		return timecode.Rate{}, timecode.RateHints{}, fmt.Errorf("--%s: %w", name, err)
+	}
+	return rate, hints, nil
+}
+
+// parseOperand parses a timecode or a frame count, with an optional sign. Frame counts are drop frame
+// if dropFrame is true.
+func parseOperand(str string, rate timecode.Rate, dropFrame bool) (*timecode.Timecode, bool, error) {
+	negative := strings.HasPrefix(str, "-")
+	value := strings.TrimLeft(str, "+-")
+	if frames, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return timecode.FromFrame(frames, rate, dropFrame), negative, nil
+	}
+	tc, err := timecode.Parse(value, rate)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", str, err)
+	}
+	return tc, negative, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runArgs(args []string, stdin string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	cases := map[string]string{
+		"add 01:00:00;00 +00:00:10;00 --rate 29.97":                    "01:00:10;00\n",
+		"add --rate 24 01:00:00:00 -10 -00:00:01:00":                   "00:59:58:14\n",
+		"add -00:00:01:00 --rate=25":                                   "-00:00:01:00\n",
+		"add 00:00:00:00.50 -00:00:00:00.75 --rate 24":                 "-00:00:00:00.25\n",
+		"add 00:00:00:00.50 -00:00:00:01.75 --rate 24 --json":          `{"timecode":"-00:00:00:01.25","frames":-1,"rate":"24","dropFrame":false,"seconds":-0.052083333333333336}` + "\n",
+		"frames 01:00:00:00 --rate 24":                                 "86400\n",
+		"frames 00:01:00;02 --rate 29.97":                              "1800\n",
+		"convert 01:00:00:00 --from 23.976 --to 25":                    "01:00:03:15\n",
+		"convert 01:00:00:00 --from 23.976 --to 25 --mode frames":      "00:57:36:00\n",
+		"convert 01:00:00:00 --from 24 --to 29.97df --mode label":      "01:00:00;00\n",
+		"convert 01:00:00;00 --from 29.97 --to 59.94":                  "01:00:00;00\n",
+		"convert 01:00:00;00 --from 29.97 --to 59.94ndf":               "00:59:56:24\n",
+		"diff 01:00:00:00 01:00:10:00 --rate 24":                       "00:00:10:00\n",
+		"diff 01:00:10:00 01:00:00:00 --rate 24":                       "-00:00:10:00\n",
+		"eval 01:00:00:00 + 90f - 2s --rate 24":                        "01:00:01:18\n",
+		"eval (b - a) * 2 --var a=01:00:00:00 --var b=a+10s --rate 24": "00:00:20:00\n",
+		"eval 10s / 4s":                       "2.5\n",
+		"eval 10s / 4s --json":                `{"kind":"number","number":"2.5"}` + "\n",
+		"eval 10s":                            "10s\n",
+		"eval 2 * 1.5s --json":                `{"kind":"duration","seconds":3}` + "\n",
+		"frames 01:00:00:00 --rate 24 --json": `{"timecode":"01:00:00:00","frames":86400,"rate":"24","dropFrame":false,"seconds":3600}` + "\n",
+	}
+	for args, expected := range cases {
+		code, stdout, stderr := runArgs(strings.Fields(args), "")
+		require.Equal(t, 0, code, "%s: %s", args, stderr)
+		require.Equal(t, expected, stdout, args)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	cases := []string{
+		"frobnicate",
+		"add 01:00:00:00",
+		"add 01:00:00:00 --rate 12.345.6",
+		"frames 01:00:00:00 01:00:00:01 --rate 24",
+		"convert 01:00:00:00 --from 24 --to 25 --mode warp",
+		"convert 01:00:00:29 --from 30 --to 25 --mode label",
+		"diff 01:00:00:00 --rate 24",
+		"frames 01:00:00:00 --rate 24 --bogus",
+		"eval",
+		"eval 01:00:00:00 + 5 --rate 24",
+		"eval a --var a",
+	}
+	for _, args := range cases {
+		code, stdout, stderr := runArgs(strings.Fields(args), "")
+		require.Equal(t, 1, code, args)
+		require.Empty(t, stdout, args)
+		require.NotEmpty(t, stderr, args)
+	}
+
+	code, _, stderr := runArgs(nil, "")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "usage:")
+}
+
+func TestRun_Batch(t *testing.T) {
+	stdin := `
+# Durations of the reels
+add 01:00:00:00 +00:20:00:00
+frames 01:00:00;00 --rate 29.97
+frames nonsense
+`
+	code, stdout, stderr := runArgs([]string{"batch", "--rate", "24"}, stdin)
+	require.Equal(t, 1, code)
+	require.Equal(t, "01:20:00:00\n107892\n", stdout)
+	require.Contains(t, stderr, "frames nonsense")
+
+	code, stdout, _ = runArgs([]string{"batch", "--json"}, "diff 01:00:00:00 01:00:00:12 --rate 24\nfrobnicate\n")
+	require.Equal(t, 1, code)
+	require.Equal(t, `{"input":"diff 01:00:00:00 01:00:00:12 --rate 24","timecode":"00:00:00:12","frames":12,"rate":"24","dropFrame":false,"seconds":0.5}
+{"input":"frobnicate","error":"unknown command \"frobnicate\""}
+`, stdout)
+
+	// Quoted arguments are kept together
+	stdin = `eval "01:00:00:00 + 90f" --rate 24
+eval '(b - a) * 2' --var "a=01:00:00:00" --var 'b=a + 10s' --rate 24
+eval "01:00:00:00
+`
+	code, stdout, stderr = runArgs([]string{"batch"}, stdin)
+	require.Equal(t, 1, code)
+	require.Equal(t, "01:00:03:18\n00:00:20:00\n", stdout)
+	require.Contains(t, stderr, "unterminated quote")
+}
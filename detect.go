@@ -0,0 +1,119 @@
+package timecode
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// DetectRate finds the frame rate of a series of presentation timestamps. See DetectRateTicks.
+func DetectRate(timestamps []time.Duration) (Rate, float64, error) {
+	ticks := make([]int64, len(timestamps))
+	for i, ts := range timestamps {
+		ticks[i] = int64(ts)
+	}
+	return DetectRateTicks(ticks, int64(time.Second))
+}
+
+// DetectRateTicks finds the frame rate of a series of presentation timestamps, expressed in ticks of
+// 1/timescale seconds (ie. 90000 for MPEG transport streams). The timestamps don't need to be in
+// presentation order, and missing frames are tolerated.
+//
+// It returns the closest registered rate along with a confidence between 0 and 1. The confidence is
+// lower when the timestamps are irregular, or when they span too little time to tell similar rates
+// apart (ie. 23.976 and 24). If no registered rate matches, the measured rate is returned from
+// RateFromFraction.
+func DetectRateTicks(ticks []int64, timescale int64) (Rate, float64, error) {
+	if timescale <= 0 {
+		return Rate{}, 0, errors.New("timescale must be positive")
+	}
+
+	// Sort the timestamps and find the durations between them
+	sorted := append([]int64(nil), ticks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var deltas []int64
+	for i := 1; i < len(sorted); i++ {
+		if delta := sorted[i] - sorted[i-1]; delta > 0 {
+			deltas = append(deltas, delta)
+		}
+	}
+	if len(deltas) == 0 {
+		return Rate{}, 0, errors.New("at least two distinct timestamps are required")
+	}
+
+	// The median duration is the most likely duration of a single frame. Longer durations
+	// are counted as multiple frames, to account for missing frames.
+	medians := append([]int64(nil), deltas...)
+	sort.Slice(medians, func(i, j int) bool { return medians[i] < medians[j] })
+	median := float64(medians[len(medians)/2])
+	var frames int64
+	var regular int
+	for _, delta := range deltas {
+		count := math.Max(1, math.Round(float64(delta)/median))
+		frames += int64(count)
+		if math.Abs(float64(delta)-count*median) <= 0.1*median {
+			regular++
+		}
+	}
+	span := sorted[len(sorted)-1] - sorted[0]
+	regularity := float64(regular) / float64(len(deltas))
+
+	// Find the registered rate with the smallest relative error, and the runner-up
+	measured := float64(frames) * float64(timescale) / float64(span)
+	best, bestErr, secondErr := Rate{}, math.Inf(1), math.Inf(1)
+	for _, rate := range Rates() {
+		expected := float64(rate.Num) / float64(rate.Den)
+		relErr := math.Abs(measured-expected) / expected
+		if relErr < bestErr {
+			best, bestErr, secondErr = rate, relErr, bestErr
+		} else if relErr < secondErr {
+			secondErr = relErr
+		}
+	}
+
+	// Timestamps are quantized to ticks, so allow for an error of a couple ticks over the span
+	tolerance := 0.0005 + 2/float64(span)
+	if bestErr > tolerance {
+		rate, err := measuredRate(measured, tolerance)
+		return rate, regularity, err
+	}
+
+	// Lower the confidence when the runner-up is nearly as close
+	distinct := 1.0
+	if secondErr <= tolerance {
+		distinct = 1 - bestErr/secondErr
+	}
+	return best, regularity * distinct, nil
+}
+
+// measuredRate creates a rate from a measured frames per second value. Whole numbers are preferred, then
+// fractions of the 1000/1001 family, when they're within the tolerance and fit at least as well. Other
+// values are rounded to a thousandth of a frame per second. Fractions are reduced.
+func measuredRate(fps, tolerance float64) (Rate, error) {
+	whole := math.Round(fps)
+	wholeErr := math.Abs(fps-whole) / fps
+	ntsc := math.Round(fps * 1.001)
+	ntscErr := math.Abs(fps-ntsc/1.001) / fps
+	var fraction [2]int
+	switch {
+	case whole > 0 && wholeErr <= tolerance && wholeErr <= ntscErr:
+		fraction = [2]int{int(whole), 1}
+	case ntsc > 0 && ntscErr <= tolerance:
+		fraction = reducedFraction(int(ntsc)*1000, 1001)
+	default:
+		fraction = reducedFraction(int(math.Round(fps*1000)), 1000)
+	}
+	return RateFromFraction(fraction[0], fraction[1])
+}
+
+// gcd gets the greatest common divisor of a and b
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
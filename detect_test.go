@@ -0,0 +1,89 @@
+package timecode_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+// timestamps creates n presentation timestamps at the given rate, rounded to ticks of the timescale
+func timestamps(rate timecode.Rate, timescale int64, n int) []int64 {
+	ticks := make([]int64, n)
+	for i := range ticks {
+		ticks[i] = int64(math.Round(float64(i) * float64(timescale) * float64(rate.Den) / float64(rate.Num)))
+	}
+	return ticks
+}
+
+func TestDetectRateTicks(t *testing.T) {
+	t.Run("known rates", func(t *testing.T) {
+		rates := []timecode.Rate{
+			timecode.Rate_23_976,
+			timecode.Rate_24,
+			timecode.Rate_25,
+			timecode.Rate_29_97,
+			timecode.Rate_30,
+			timecode.Rate_59_94,
+			timecode.Rate_60,
+		}
+		for _, rate := range rates {
+			for _, timescale := range []int64{1000, 90000} {
+				detected, confidence, err := timecode.DetectRateTicks(timestamps(rate, timescale, 300), timescale)
+				require.NoError(t, err)
+				require.Equal(t, rate, detected, "rate %s, timescale %d", rate.String(), timescale)
+				require.Greater(t, confidence, 0.5, "rate %s, timescale %d", rate.String(), timescale)
+			}
+		}
+	})
+	t.Run("reordered and missing frames", func(t *testing.T) {
+		ticks := timestamps(timecode.Rate_29_97, 90000, 100)
+		ticks[1], ticks[3] = ticks[3], ticks[1]
+		ticks = append(ticks[:50], ticks[53:]...)
+		rate, confidence, err := timecode.DetectRateTicks(ticks, 90000)
+		require.NoError(t, err)
+		require.Equal(t, timecode.Rate_29_97, rate)
+		require.Greater(t, confidence, 0.9)
+	})
+	t.Run("short spans have low confidence", func(t *testing.T) {
+		_, confidence, err := timecode.DetectRateTicks(timestamps(timecode.Rate_24, 1000, 3), 1000)
+		require.NoError(t, err)
+		require.Less(t, confidence, 0.5)
+	})
+	t.Run("nonstandard rates", func(t *testing.T) {
+		rate, _, err := timecode.DetectRateTicks(timestamps(timecode.Rate{Num: 50, Den: 1}, 90000, 100), 90000)
+		require.NoError(t, err)
+		require.Equal(t, 50, rate.Num)
+		require.Equal(t, 1, rate.Den)
+
+		rate, _, err = timecode.DetectRateTicks(timestamps(timecode.Rate{Num: 48000, Den: 1001}, 90000, 100), 90000)
+		require.NoError(t, err)
+		require.Equal(t, 48000, rate.Num)
+		require.Equal(t, 1001, rate.Den)
+
+		// Whole rates aren't mistaken for nearby fractions of the 1000/1001 family
+		rate, _, err = timecode.DetectRateTicks(timestamps(timecode.Rate{Num: 1200, Den: 1}, 120000, 100), 120000)
+		require.NoError(t, err)
+		require.Equal(t, 1200, rate.Num)
+		require.Equal(t, 1, rate.Den)
+	})
+	t.Run("not enough timestamps", func(t *testing.T) {
+		_, _, err := timecode.DetectRateTicks([]int64{100}, 90000)
+		require.Error(t, err)
+		_, _, err = timecode.DetectRateTicks([]int64{100, 100}, 90000)
+		require.Error(t, err)
+	})
+}
+
+func TestDetectRate(t *testing.T) {
+	var durations []time.Duration
+	for _, tick := range timestamps(timecode.Rate_23_976, int64(time.Second), 48) {
+		durations = append(durations, time.Duration(tick))
+	}
+	rate, confidence, err := timecode.DetectRate(durations)
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_23_976, rate)
+	require.Greater(t, confidence, 0.9)
+}
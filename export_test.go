@@ -0,0 +1,11 @@
+package timecode
+
+import "testing"
+
+// UseTestRegistry replaces the registry of known rates with one that only has the built-in rates, so that
+// the rates a test registers don't leak into other tests. The registry is restored when the test finishes.
+func UseTestRegistry(t testing.TB) {
+	saved := registry
+	registry = newRateRegistry(builtinRates)
+	t.Cleanup(func() { registry = saved })
+}
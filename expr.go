@@ -0,0 +1,530 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ValueKind is the kind of value that an expression evaluates to
+type ValueKind int
+
+const (
+	// KindNumber is a plain number, such as a multiplier
+	KindNumber ValueKind = iota
+	// KindDuration is a length of time, such as the difference between two timecodes
+	KindDuration
+	// KindTimecode is a point in time
+	KindTimecode
+)
+
+// String gets the name of the kind of value
+func (k ValueKind) String() string {
+	switch k {
+	case KindDuration:
+		return "duration"
+	case KindTimecode:
+		return "timecode"
+	default:
+		return "number"
+	}
+}
+
+// Value is the result of evaluating an expression. Timecodes and durations are kept as exact times in
+// seconds, along with the rate they're shown in.
+type Value struct {
+	kind      ValueKind
+	value     Rational
+	rate      Rate
+	dropFrame bool
+}
+
+// NumberValue creates a number value
+func NumberValue(n Rational) Value {
+	return Value{kind: KindNumber, value: n}
+}
+
+// TimecodeValue creates a value for the point in time of a timecode
+func TimecodeValue(tc *Timecode) Value {
+	return Value{KindTimecode, tc.Seconds(), tc.rate, tc.dropFrame}
+}
+
+// DurationValue creates a value for the length of time of a timecode
+func DurationValue(tc *Timecode) Value {
+	return Value{KindDuration, tc.Seconds(), tc.rate, tc.dropFrame}
+}
+
+// Kind gets the kind of value
+func (v Value) Kind() ValueKind {
+	return v.kind
+}
+
+// Number gets the value of a number, or the time in seconds of a timecode or duration
+func (v Value) Number() Rational {
+	return v.value
+}
+
+// Timecode gets a timecode or duration as a timecode. Timecodes are rounded down to the frame that
+// contains them, and durations are rounded towards zero to a whole number of frames. It returns nil for
+// numbers, and for durations in seconds that were evaluated without a rate.
+func (v Value) Timecode() *Timecode {
+	if v.kind == KindNumber || v.rate.Num <= 0 {
+		return nil
+	}
+	frames := v.rate.Frames(v.value)
+	frame := frames.Floor()
+	if v.kind == KindDuration && frames.Sign() < 0 {
+		frame = frames.Ceil()
+	}
+	return FromFrame(frame, v.rate, v.dropFrame)
+}
+
+// String formats the value. Timecodes and durations before zero are formatted with a minus sign, and
+// durations without a rate are formatted in seconds (ie. 1.5s).
+func (v Value) String() string {
+	tc := v.Timecode()
+	switch {
+	case v.kind == KindNumber:
+		return formatNumber(v.value)
+	case tc == nil:
+		return formatNumber(v.value) + "s"
+	}
+	if tc.frame < 0 {
+		return "-" + tc.withFrame(-tc.frame).String()
+	}
+	return tc.String()
+}
+
+// formatNumber formats a number as an integer, or as a decimal with up to 6 places
+func formatNumber(n Rational) string {
+	if n.IsInt() {
+		return n.String()
+	}
+	return strings.TrimRight(n.FloatString(6), "0")
+}
+
+// ExpressionEnv is the environment an expression is evaluated in
+type ExpressionEnv struct {
+	// Rate is used for timecodes and frame counts that don't have a rate annotation
+	Rate Rate
+	// DropFrame is used for durations that aren't based on a timecode
+	DropFrame bool
+	// Vars are the values of the variables in the expression
+	Vars map[string]Value
+}
+
+// Expression is a parsed timecode expression. Expressions are made up of:
+//
+//   - timecodes, such as 01:00:00:00 or 01:00:00;00, with optional sub-frames or a field mark
+//     (ie. 01:00:00.12)
+//   - frame counts, such as 90f
+//   - seconds, such as 10s or 1.5s
+//   - plain numbers, such as 2
+//   - variables, such as tc1
+//   - the operators + - * / and parentheses
+//
+// Timecodes and frame counts can be annotated with a rate (ie. 01:00:00:00@25 or 90f@23.976). Otherwise
+// they use the rate of the environment.
+//
+// Subtracting two timecodes gives a duration, and adding a duration to a timecode gives a timecode.
+// Adding two timecodes treats the second one as a duration. Durations can be multiplied and divided by
+// numbers, and dividing two durations gives a number.
+type Expression struct {
+	root exprNode
+}
+
+// ParseExpression parses a timecode expression
+func ParseExpression(str string) (*Expression, error) {
+	tokens, err := lexExpression(str)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return &Expression{root}, nil
+}
+
+// Eval evaluates the expression in an environment
+func (e *Expression) Eval(env ExpressionEnv) (Value, error) {
+	return e.root.eval(env)
+}
+
+// Evaluate parses and evaluates a timecode expression in an environment
+func Evaluate(str string, env ExpressionEnv) (Value, error) {
+	expr, err := ParseExpression(str)
+	if err != nil {
+		return Value{}, err
+	}
+	return expr.Eval(env)
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenLiteral
+	tokenIdent
+	tokenOperator
+)
+
+// exprToken is a token of an expression. Literals have their unit and rate annotation split out.
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+
+	unit, rate string
+}
+
+// lexExpression splits an expression into tokens
+func lexExpression(str string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(str)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/()", r):
+			tokens = append(tokens, exprToken{kind: tokenOperator, text: string(r), pos: start})
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			// Literals are digits and separators, followed by an optional unit and rate annotation
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(":;.,", runes[i])) {
+				i++
+			}
+			tok := exprToken{kind: tokenLiteral, text: string(runes[start:i]), pos: start}
+			unitStart := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tok.unit = string(runes[unitStart:i])
+			if i < len(runes) && runes[i] == '@' {
+				i++
+				rateStart := i
+				for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '.') {
+					i++
+				}
+				if rateStart == i {
+					return nil, fmt.Errorf("missing rate after @ at position %d", rateStart)
+				}
+				tok.rate = string(runes[rateStart:i])
+			}
+			tokens = append(tokens, tok)
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, start)
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEnd, text: "end of expression", pos: len(runes)}), nil
+}
+
+// exprParser is a recursive descent parser for expressions
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEnd {
+		p.pos++
+	}
+	return tok
+}
+
+// parseSum parses terms separated by + and -
+func (p *exprParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "+" || tok.text == "-"); tok = p.peek() {
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{tok.text[0], left, right}
+	}
+	return left, nil
+}
+
+// parseProduct parses factors separated by * and /
+func (p *exprParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "*" || tok.text == "/"); tok = p.peek() {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{tok.text[0], left, right}
+	}
+	return left, nil
+}
+
+// parseUnary parses a factor with optional signs
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if tok := p.peek(); tok.kind == tokenOperator && (tok.text == "+" || tok.text == "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if tok.text == "+" {
+			return operand, nil
+		}
+		return &negateNode{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a literal, a variable or a parenthesized expression
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokenLiteral:
+		return parseLiteral(tok)
+	case tok.kind == tokenIdent:
+		return &varNode{tok.text}, nil
+	case tok.kind == tokenOperator && tok.text == "(":
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.text != ")" {
+			return nil, fmt.Errorf("expected ) at position %d", closing.pos)
+		}
+		return inner, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+// parseLiteral parses a timecode, frame count, seconds or number literal
+func parseLiteral(tok exprToken) (exprNode, error) {
+	lit := &literalNode{text: tok.text, unit: tok.unit}
+	if tok.rate != "" {
+		rate, _, err := ParseRateHints(tok.rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q at position %d", tok.rate, tok.pos)
+		}
+		lit.rate = &rate
+	}
+
+	// Timecodes are checked here, and parsed when the rate is known
+	if strings.ContainsAny(tok.text, ":;") {
+		if tok.unit != "" || !TimecodeRegex.MatchString(tok.text) && !hasFieldMark(tok.text) {
+			return nil, fmt.Errorf("invalid timecode %q at position %d", tok.text+tok.unit, tok.pos)
+		}
+		return lit, nil
+	}
+
+	n, err := ParseRational(tok.text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+	}
+	lit.value = n
+	switch {
+	case tok.unit == "f" && !n.IsInt():
+		return nil, fmt.Errorf("frame count %q must be a whole number", tok.text)
+	case tok.unit == "s" && lit.rate != nil:
+		return nil, fmt.Errorf("seconds %q can't have a rate", tok.text)
+	case tok.unit == "" && lit.rate != nil:
+		return nil, fmt.Errorf("number %q can't have a rate", tok.text)
+	case tok.unit != "" && tok.unit != "f" && tok.unit != "s":
+		return nil, fmt.Errorf("unknown unit %q at position %d", tok.unit, tok.pos)
+	}
+	return lit, nil
+}
+
+// exprNode is a node of a parsed expression
+type exprNode interface {
+	eval(env ExpressionEnv) (Value, error)
+}
+
+type literalNode struct {
+	text, unit string
+	value      Rational
+	rate       *Rate
+}
+
+func (n *literalNode) eval(env ExpressionEnv) (Value, error) {
+	rate := env.Rate
+	if n.rate != nil {
+		rate = *n.rate
+	}
+	needsRate := n.unit == "f" || strings.ContainsAny(n.text, ":;")
+	if needsRate && rate.Num <= 0 {
+		return Value{}, fmt.Errorf("%s%s needs a rate", n.text, n.unit)
+	}
+
+	switch {
+	case strings.ContainsAny(n.text, ":;"):
+		tc, err := Parse(n.text, rate)
+		if err != nil {
+			return Value{}, fmt.Errorf("%s: %w", n.text, err)
+		}
+		return TimecodeValue(tc), nil
+	case n.unit == "f":
+		return Value{KindDuration, rate.Seconds(n.value.Floor()), rate, env.DropFrame}, nil
+	case n.unit == "s":
+		return Value{KindDuration, n.value, rate, env.DropFrame}, nil
+	default:
+		return NumberValue(n.value), nil
+	}
+}
+
+type varNode struct {
+	name string
+}
+
+func (n *varNode) eval(env ExpressionEnv) (Value, error) {
+	v, ok := env.Vars[n.name]
+	if !ok {
+		return Value{}, fmt.Errorf("undefined variable %s", n.name)
+	}
+	return v, nil
+}
+
+type negateNode struct {
+	operand exprNode
+}
+
+// eval negates the operand. Negating a timecode gives a duration.
+func (n *negateNode) eval(env ExpressionEnv) (Value, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	v.value = v.value.Neg()
+	if v.kind == KindTimecode {
+		v.kind = KindDuration
+	}
+	return v, nil
+}
+
+type binaryNode struct {
+	op          byte
+	left, right exprNode
+}
+
+func (n *binaryNode) eval(env ExpressionEnv) (Value, error) {
+	a, err := n.left.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	b, err := n.right.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	switch n.op {
+	case '+':
+		return addValues(a, b)
+	case '-':
+		return subValues(a, b)
+	case '*':
+		return mulValues(a, b)
+	default:
+		return divValues(a, b)
+	}
+}
+
+// errMixedNumber is returned when a number is added to or subtracted from a timecode or duration, which
+// is ambiguous because the number has no unit
+var errMixedNumber = errors.New("numbers need a unit (ie. f or s) to be added to timecodes and durations")
+
+// addValues adds two values. The result keeps the rate of the timecode, or the left value if neither is
+// a timecode.
+func addValues(a, b Value) (Value, error) {
+	if (a.kind == KindNumber) != (b.kind == KindNumber) {
+		return Value{}, errMixedNumber
+	}
+	result := a
+	if b.kind == KindTimecode && a.kind != KindTimecode {
+		result = b
+	}
+	result.value = a.value.Add(b.value)
+	return withRateOf(result, a, b), nil
+}
+
+// withRateOf gives a result without a rate the rate of whichever operand has one, so that seconds
+// evaluated without a rate pick up the rate of the timecodes and frame counts they're combined with
+func withRateOf(result, a, b Value) Value {
+	for _, v := range []Value{a, b} {
+		if result.rate.Num <= 0 && v.rate.Num > 0 {
+			result.rate, result.dropFrame = v.rate, v.dropFrame
+		}
+	}
+	return result
+}
+
+// subValues subtracts two values. Subtracting two timecodes gives a duration.
+func subValues(a, b Value) (Value, error) {
+	switch {
+	case (a.kind == KindNumber) != (b.kind == KindNumber):
+		return Value{}, errMixedNumber
+	case a.kind == KindDuration && b.kind == KindTimecode:
+		return Value{}, errors.New("can't subtract a timecode from a duration")
+	}
+	result := a
+	if a.kind == KindTimecode && b.kind == KindTimecode {
+		result.kind = KindDuration
+	}
+	result.value = a.value.Sub(b.value)
+	return withRateOf(result, a, b), nil
+}
+
+// mulValues multiplies two values. Timecodes are multiplied as durations.
+func mulValues(a, b Value) (Value, error) {
+	if a.kind == KindNumber {
+		a, b = b, a
+	}
+	if b.kind != KindNumber {
+		return Value{}, errors.New("can't multiply two durations")
+	}
+	if a.kind == KindTimecode {
+		a.kind = KindDuration
+	}
+	a.value = a.value.Mul(b.value)
+	return a, nil
+}
+
+// divValues divides two values. Timecodes are divided as durations, and dividing two durations gives a
+// number.
+func divValues(a, b Value) (Value, error) {
+	if b.value.Sign() == 0 {
+		return Value{}, errors.New("division by zero")
+	}
+	switch {
+	case a.kind == KindNumber && b.kind != KindNumber:
+		return Value{}, errors.New("can't divide a number by a duration")
+	case b.kind != KindNumber:
+		return NumberValue(a.value.Div(b.value)), nil
+	}
+	if a.kind == KindTimecode {
+		a.kind = KindDuration
+	}
+	a.value = a.value.Div(b.value)
+	return a, nil
+}
@@ -0,0 +1,152 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	env := timecode.ExpressionEnv{
+		Rate: timecode.Rate_24,
+		Vars: map[string]timecode.Value{
+			"tc1": timecode.TimecodeValue(timecode.MustParse("01:00:00:00", timecode.Rate_24)),
+			"tc2": timecode.TimecodeValue(timecode.MustParse("01:00:10:12", timecode.Rate_24)),
+		},
+	}
+	cases := []struct {
+		expr     string
+		kind     timecode.ValueKind
+		expected string
+	}{
+		{"01:00:00:00 + 90f - 2s", timecode.KindTimecode, "01:00:01:18"},
+		{"(tc2 - tc1) * 2", timecode.KindDuration, "00:00:21:00"},
+		{"tc1 - tc2", timecode.KindDuration, "-00:00:10:12"},
+		{"tc1 + (tc2 - tc1) / 2", timecode.KindTimecode, "01:00:05:06"},
+		{"(tc2 - tc1) / 12f", timecode.KindNumber, "21"},
+		{"10s / 4s", timecode.KindNumber, "2.5"},
+		{"3 * (2 + 4) / 9", timecode.KindNumber, "2"},
+		{"01:00:00:00 + 00:00:10:00", timecode.KindTimecode, "01:00:10:00"},
+		{"2 * 00:00:01:12", timecode.KindDuration, "00:00:03:00"},
+		{"-90f", timecode.KindDuration, "-00:00:03:18"},
+		{"+90f", timecode.KindDuration, "00:00:03:18"},
+		{"1.5s", timecode.KindDuration, "00:00:01:12"},
+		{"01:00:00;00@29.97 + 10s", timecode.KindTimecode, "01:00:09;29"},
+		{"01:00:00;00@29.97 + 300f@29.97", timecode.KindTimecode, "01:00:10;00"},
+		{"01:00:00:00@25 - 01:00:00:00", timecode.KindDuration, "00:00:00:00"},
+		{"90f@25", timecode.KindDuration, "00:00:03:15"},
+		{"0f + 90f@25", timecode.KindDuration, "00:00:03:14"},
+		{"01:00:00.12 + 1f", timecode.KindTimecode, "01:00:00:13"},
+		{"01:00:00.12 - 01:00:00:12", timecode.KindDuration, "00:00:00:00"},
+		{"(01:00:00.12 - 01:00:00:12) * 4", timecode.KindDuration, "00:00:00:02"},
+		{"00:01:00,02@29.97 - 00:01:00;02@29.97", timecode.KindDuration, "00:00:00;00"},
+	}
+	for _, c := range cases {
+		v, err := timecode.Evaluate(c.expr, env)
+		require.NoError(t, err, c.expr)
+		require.Equal(t, c.kind, v.Kind(), c.expr)
+		require.Equal(t, c.expected, v.String(), c.expr)
+	}
+}
+
+func TestEvaluate_Timecode(t *testing.T) {
+	// Durations round towards zero, and timecodes round down to the frame that contains them
+	env := timecode.ExpressionEnv{Rate: timecode.Rate_29_97, DropFrame: true}
+	v, err := timecode.Evaluate("10s", env)
+	require.NoError(t, err)
+	require.Equal(t, int64(299), v.Timecode().Frame())
+	require.True(t, v.Timecode().DropFrame())
+	v, err = timecode.Evaluate("-10s", env)
+	require.NoError(t, err)
+	require.Equal(t, int64(-299), v.Timecode().Frame())
+	v, err = timecode.Evaluate("00:00:00;00 + 10s", env)
+	require.NoError(t, err)
+	require.Equal(t, int64(299), v.Timecode().Frame())
+	v, err = timecode.Evaluate("00:00:00;00 - 10s", env)
+	require.NoError(t, err)
+	require.Equal(t, int64(-300), v.Timecode().Frame())
+
+	v, err = timecode.Evaluate("2 / 3", env)
+	require.NoError(t, err)
+	require.Nil(t, v.Timecode())
+	require.Equal(t, timecode.NewRational(2, 3), v.Number())
+}
+
+func TestEvaluate_WithoutRate(t *testing.T) {
+	// Seconds evaluated without a rate stay in seconds, until they're combined with something that has one
+	cases := []struct {
+		expr     string
+		kind     timecode.ValueKind
+		expected string
+	}{
+		{"10s", timecode.KindDuration, "10s"},
+		{"2 * 3s", timecode.KindDuration, "6s"},
+		{"-1.5s", timecode.KindDuration, "-1.5s"},
+		{"10s + 90f@25", timecode.KindDuration, "00:00:13:15"},
+		{"90f@25 - 10s", timecode.KindDuration, "-00:00:06:10"},
+		{"10s + 01:00:00:00@24", timecode.KindTimecode, "01:00:10:00"},
+	}
+	for _, c := range cases {
+		v, err := timecode.Evaluate(c.expr, timecode.ExpressionEnv{})
+		require.NoError(t, err, c.expr)
+		require.Equal(t, c.kind, v.Kind(), c.expr)
+		require.Equal(t, c.expected, v.String(), c.expr)
+	}
+
+	v, err := timecode.Evaluate("2 * 3s", timecode.ExpressionEnv{})
+	require.NoError(t, err)
+	require.Equal(t, timecode.KindDuration, v.Kind())
+	require.Nil(t, v.Timecode())
+	require.Equal(t, timecode.NewRational(6, 1), v.Number())
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	env := timecode.ExpressionEnv{Rate: timecode.Rate_24}
+	for _, expr := range []string{
+		"",
+		"01:00:00:00 +",
+		"(01:00:00:00",
+		"01:00:00:00)",
+		"01:00:00:00 + 5",
+		"5 - 10s",
+		"10s - 01:00:00:00",
+		"10s * 10s",
+		"2 / 10s",
+		"10s / 0",
+		"10s / 0f",
+		"1:00:00:00",
+		"01:00:00:00x",
+		"1.5f",
+		"10x",
+		"10s@25",
+		"10@25",
+		"90f@",
+		"90f@fast",
+		"tc3",
+		"10s % 2",
+	} {
+		_, err := timecode.Evaluate(expr, env)
+		require.Error(t, err, expr)
+	}
+
+	// Timecodes and frame counts need a rate
+	_, err := timecode.Evaluate("90f", timecode.ExpressionEnv{})
+	require.Error(t, err)
+	_, err = timecode.Evaluate("90f@24 + 1s", timecode.ExpressionEnv{})
+	require.NoError(t, err)
+}
+
+func TestParseExpression(t *testing.T) {
+	expr, err := timecode.ParseExpression("start + 10f")
+	require.NoError(t, err)
+	for _, rate := range []timecode.Rate{timecode.Rate_24, timecode.Rate_25} {
+		start := timecode.MustParse("01:00:00:00", rate)
+		v, err := expr.Eval(timecode.ExpressionEnv{
+			Rate: rate,
+			Vars: map[string]timecode.Value{"start": timecode.TimecodeValue(start)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "01:00:00:10", v.String())
+	}
+}
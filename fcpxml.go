@@ -0,0 +1,161 @@
+package timecode
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseFCPXMLTime parses a time from an FCPXML document, such as "3600s" or "1001/30000s", in seconds
+func ParseFCPXMLTime(str string) (Rational, error) {
+	value, ok := strings.CutSuffix(strings.TrimSpace(str), "s")
+	if !ok || value == "" || strings.ContainsAny(value, "eE") {
+		return Rational{}, fmt.Errorf("invalid FCPXML time %q", str)
+	}
+	seconds, err := ParseRational(value)
+	if err != nil {
+		return Rational{}, fmt.Errorf("invalid FCPXML time %q", str)
+	}
+	return seconds, nil
+}
+
+// FormatFCPXMLTime formats a time in seconds for an FCPXML document, as a whole number of seconds (ie.
+// "3600s") or as a fraction in lowest terms (ie. "1001/30000s")
+func FormatFCPXMLTime(seconds Rational) string {
+	return seconds.String() + "s"
+}
+
+// FCPXMLTimecode creates the timecode of a time from an FCPXML document, which is the frame that contains
+// the time
+func FCPXMLTimecode(str string, rate Rate, dropFrame bool) (*Timecode, error) {
+	seconds, err := ParseFCPXMLTime(str)
+	if err != nil {
+		return nil, err
+	}
+	return FromSeconds(seconds, rate, dropFrame), nil
+}
+
+// FCPXMLTime formats the time of this timecode for an FCPXML document
+func (t *Timecode) FCPXMLTime() string {
+	return FormatFCPXMLTime(t.Seconds())
+}
+
+// RateFromFCPXMLFrameDuration gets the rate of an FCPXML format from its frame duration (ie. "1001/30000s")
+func RateFromFCPXMLFrameDuration(str string) (Rate, error) {
+	duration, err := ParseFCPXMLTime(str)
+	if err != nil {
+		return Rate{}, err
+	}
+	num, den, ok := duration.Int64s()
+	if !ok || num <= 0 || num > 1<<31 || den > 1<<31 {
+		return Rate{}, fmt.Errorf("invalid FCPXML frame duration %q", str)
+	}
+	return RateFromFraction(int(den), int(num))
+}
+
+// FCPXMLFrameDuration formats the frame duration of a rate for an FCPXML format (ie. "1001/30000s")
+func FCPXMLFrameDuration(rate Rate) string {
+	return fmt.Sprintf("%d/%ds", rate.Den, rate.Num)
+}
+
+// FCPXMLSequence is the timing of a sequence in an FCPXML document
+type FCPXMLSequence struct {
+	// Name is the name of the project that contains the sequence
+	Name      string
+	Rate      Rate
+	DropFrame bool
+	// Start is the time of the start of the sequence, in seconds
+	Start Rational
+}
+
+// StartTimecode gets the timecode at the start of the sequence
+func (s *FCPXMLSequence) StartTimecode() *Timecode {
+	return FromSeconds(s.Start, s.Rate, s.DropFrame)
+}
+
+// Timecode resolves a time in the timeline of the sequence, such as the offset of a clip in its spine, to
+// the timecode that's displayed for it. Times in the timeline include the start of the sequence.
+func (s *FCPXMLSequence) Timecode(str string) (*Timecode, error) {
+	return FCPXMLTimecode(str, s.Rate, s.DropFrame)
+}
+
+// ReadFCPXMLSequences reads the timing of the sequences in an FCPXML document, using the frame durations of
+// the formats in its resources
+func ReadFCPXMLSequences(r io.Reader) ([]FCPXMLSequence, error) {
+	type sequenceRef struct {
+		name, format, tcStart, tcFormat string
+	}
+	formats := make(map[string]string)
+	var refs []sequenceRef
+	var projects []string
+
+	decoder := xml.NewDecoder(r)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid FCPXML: %w", err)
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			attrs := make(map[string]string)
+			for _, attr := range el.Attr {
+				attrs[attr.Name.Local] = attr.Value
+			}
+			switch el.Name.Local {
+			case "format":
+				formats[attrs["id"]] = attrs["frameDuration"]
+			case "project":
+				projects = append(projects, attrs["name"])
+			case "sequence":
+				ref := sequenceRef{format: attrs["format"], tcStart: attrs["tcStart"], tcFormat: attrs["tcFormat"]}
+				if len(projects) > 0 {
+					ref.name = projects[len(projects)-1]
+				}
+				refs = append(refs, ref)
+			}
+		case xml.EndElement:
+			if el.Name.Local == "project" && len(projects) > 0 {
+				projects = projects[:len(projects)-1]
+			}
+		}
+	}
+
+	// Resolve the formats of the sequences, which can be declared after them
+	sequences := make([]FCPXMLSequence, 0, len(refs))
+	for _, ref := range refs {
+		frameDuration, ok := formats[ref.format]
+		if !ok || frameDuration == "" {
+			return nil, fmt.Errorf("sequence format %q has no frame duration", ref.format)
+		}
+		rate, err := RateFromFCPXMLFrameDuration(frameDuration)
+		if err != nil {
+			return nil, err
+		}
+		seq := FCPXMLSequence{Name: ref.name, Rate: rate}
+		switch ref.tcFormat {
+		case "DF":
+			if !rate.IsDropFrameCapable() {
+				return nil, fmt.Errorf("rate %s can't be drop frame", rate.Str)
+			}
+			seq.DropFrame = true
+		case "", "NDF":
+		default:
+			return nil, fmt.Errorf("invalid tcFormat %q", ref.tcFormat)
+		}
+		if ref.tcStart != "" {
+			if seq.Start, err = ParseFCPXMLTime(ref.tcStart); err != nil {
+				return nil, err
+			}
+		}
+		sequences = append(sequences, seq)
+	}
+	if len(sequences) == 0 {
+		return nil, errors.New("FCPXML document has no sequences")
+	}
+	return sequences, nil
+}
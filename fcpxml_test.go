@@ -0,0 +1,143 @@
+package timecode_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+const testFCPXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE fcpxml>
+<fcpxml version="1.10">
+	<resources>
+		<format id="r1" name="FFVideoFormat1080p2997" frameDuration="1001/30000s" width="1920" height="1080"/>
+		<format id="r2" name="FFVideoFormat1080p24" frameDuration="100/2400s" width="1920" height="1080"/>
+	</resources>
+	<library>
+		<event name="Day 1">
+			<project name="Promo">
+				<sequence format="r1" duration="36036/30000s" tcStart="107999892/30000s" tcFormat="DF">
+					<spine>
+						<asset-clip ref="r3" offset="107999892/30000s" duration="12012/30000s"/>
+						<asset-clip ref="r4" offset="108011904/30000s" duration="24024/30000s"/>
+					</spine>
+				</sequence>
+			</project>
+			<project name="Trailer">
+				<sequence format="r2" duration="240/24s" tcStart="3600s">
+					<spine/>
+				</sequence>
+			</project>
+		</event>
+	</library>
+</fcpxml>
+`
+
+func TestParseFCPXMLTime(t *testing.T) {
+	cases := map[string]timecode.Rational{
+		"0s":            timecode.RationalFromInt(0),
+		"3600s":         timecode.RationalFromInt(3600),
+		"3600/1s":       timecode.RationalFromInt(3600),
+		"1001/30000s":   timecode.NewRational(1001, 30000),
+		"100/2400s":     timecode.NewRational(1, 24),
+		" 12012/30000s": timecode.NewRational(1001, 2500),
+	}
+	for str, expected := range cases {
+		seconds, err := timecode.ParseFCPXMLTime(str)
+		require.NoError(t, err, str)
+		require.Equal(t, 0, expected.Cmp(seconds), str)
+	}
+	for _, str := range []string{"", "s", "3600", "1/0s", "1e3s", "fast s", "3600ms"} {
+		_, err := timecode.ParseFCPXMLTime(str)
+		require.Error(t, err, str)
+	}
+	require.Equal(t, "3600s", timecode.FormatFCPXMLTime(timecode.RationalFromInt(3600)))
+	require.Equal(t, "1001/30000s", timecode.FormatFCPXMLTime(timecode.NewRational(1001, 30000)))
+}
+
+func TestFCPXMLTimecode(t *testing.T) {
+	tc, err := timecode.FCPXMLTimecode("107999892/30000s", timecode.Rate_29_97, true)
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00;00", tc.String())
+	require.Equal(t, "8999991/2500s", tc.FCPXMLTime())
+
+	tc, err = timecode.FCPXMLTimecode("3600s", timecode.Rate_24, false)
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00:00", tc.String())
+	require.Equal(t, "3600s", tc.FCPXMLTime())
+
+	// Times round trip through timecodes
+	for _, rate := range []timecode.Rate{timecode.Rate_23_976, timecode.Rate_25, timecode.Rate_59_94} {
+		tc := timecode.FromFrame(123457, rate, rate.IsDropFrameCapable())
+		parsed, err := timecode.FCPXMLTimecode(tc.FCPXMLTime(), rate, rate.IsDropFrameCapable())
+		require.NoError(t, err)
+		require.Equal(t, tc.String(), parsed.String())
+	}
+
+	_, err = timecode.FCPXMLTimecode("3600", timecode.Rate_24, false)
+	require.Error(t, err)
+}
+
+func TestFCPXMLFrameDuration(t *testing.T) {
+	cases := map[string]timecode.Rate{
+		"1001/30000s": timecode.Rate_29_97,
+		"1001/24000s": timecode.Rate_23_976,
+		"100/2400s":   timecode.Rate_24,
+		"1/25s":       timecode.Rate_25,
+		"1001/60000s": timecode.Rate_59_94,
+	}
+	for str, expected := range cases {
+		rate, err := timecode.RateFromFCPXMLFrameDuration(str)
+		require.NoError(t, err, str)
+		require.Equal(t, expected, rate, str)
+	}
+	require.Equal(t, "1001/30000s", timecode.FCPXMLFrameDuration(timecode.Rate_29_97))
+	require.Equal(t, "1/24s", timecode.FCPXMLFrameDuration(timecode.Rate_24))
+
+	for _, str := range []string{"0s", "-1/25s", "1/25"} {
+		_, err := timecode.RateFromFCPXMLFrameDuration(str)
+		require.Error(t, err, str)
+	}
+}
+
+func TestReadFCPXMLSequences(t *testing.T) {
+	sequences, err := timecode.ReadFCPXMLSequences(strings.NewReader(testFCPXML))
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+
+	promo := sequences[0]
+	require.Equal(t, "Promo", promo.Name)
+	require.Equal(t, timecode.Rate_29_97, promo.Rate)
+	require.True(t, promo.DropFrame)
+	require.Equal(t, "01:00:00;00", promo.StartTimecode().String())
+	tc, err := promo.Timecode("108011904/30000s")
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00;12", tc.String())
+
+	trailer := sequences[1]
+	require.Equal(t, "Trailer", trailer.Name)
+	require.Equal(t, timecode.Rate_24, trailer.Rate)
+	require.False(t, trailer.DropFrame)
+	require.Equal(t, "01:00:00:00", trailer.StartTimecode().String())
+
+	for _, doc := range []string{
+		`<fcpxml><library/></fcpxml>`,
+		`<fcpxml><sequence format="r1"/></fcpxml>`,
+		`<fcpxml><resources><format id="r1" frameDuration="1/25s"/></resources><sequence format="r1" tcFormat="DF"/></fcpxml>`,
+		`<fcpxml><resources><format id="r1" frameDuration="1/25s"/></resources><sequence format="r1" tcFormat="XDF"/></fcpxml>`,
+		`<fcpxml><resources><format id="r1" frameDuration="1/25s"/></resources><sequence format="r1" tcStart="soon"/></fcpxml>`,
+		`<fcpxml><sequence`,
+	} {
+		_, err := timecode.ReadFCPXMLSequences(strings.NewReader(doc))
+		require.Error(t, err, doc)
+	}
+
+	// Formats can be declared after the sequences
+	sequences, err = timecode.ReadFCPXMLSequences(strings.NewReader(
+		`<fcpxml><sequence format="r1" tcStart="10s"/><resources><format id="r1" frameDuration="1/25s"/></resources></fcpxml>`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, "00:00:10:00", sequences[0].StartTimecode().String())
+}
@@ -0,0 +1,95 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// FilmGauge describes how frames are laid out along a strip of film, as the number of perforations
+// per foot of film and per frame
+type FilmGauge struct {
+	Name          string
+	PerfsPerFoot  int
+	PerfsPerFrame int
+}
+
+var (
+	Gauge_35mm_4Perf = FilmGauge{"35mm 4-perf", 64, 4}
+	Gauge_35mm_3Perf = FilmGauge{"35mm 3-perf", 64, 3}
+	Gauge_35mm_2Perf = FilmGauge{"35mm 2-perf", 64, 2}
+	Gauge_16mm       = FilmGauge{"16mm", 40, 1}
+)
+
+// footFrame gets the index of the first frame that starts within the given foot. Gauges where frames
+// don't divide evenly into a foot (ie. 35mm 3-perf) have a varying number of frames per foot. Invalid
+// gauges (such as the zero FilmGauge) have all their frames in the first foot.
+func (g FilmGauge) footFrame(feet int64) int64 {
+	if !g.valid() {
+		return 0
+	}
+	return NewRational(feet*int64(g.PerfsPerFoot), int64(g.PerfsPerFrame)).Ceil()
+}
+
+// valid checks if the gauge has perforations, so that frames and feet can be converted
+func (g FilmGauge) valid() bool {
+	return g.PerfsPerFoot > 0 && g.PerfsPerFrame > 0
+}
+
+// FeetFramesRegex is the pattern for a valid feet+frames value
+var FeetFramesRegex = regexp.MustCompile(`^(-?\d+)\+(\d+)$`)
+
+// FeetFrames represents a position on a strip of film as a number of feet, plus a number of frames
+// into the foot
+type FeetFrames struct {
+	Feet, Frames int64
+	Gauge        FilmGauge
+}
+
+// FeetFramesFromFrame creates the feet+frames value for a frame index on the given film gauge. Timecodes
+// can be converted in the same way, since they're framers.
+func FeetFramesFromFrame(frame Framer, gauge FilmGauge) FeetFrames {
+	if !gauge.valid() {
+		return FeetFrames{Frames: frame.Frame(), Gauge: gauge}
+	}
+	feet := NewRational(frame.Frame()*int64(gauge.PerfsPerFrame), int64(gauge.PerfsPerFoot)).Floor()
+	return FeetFrames{
+		Feet:   feet,
+		Frames: frame.Frame() - gauge.footFrame(feet),
+		Gauge:  gauge,
+	}
+}
+
+// ParseFeetFrames parses a feet+frames value from a string (ie. "1234+07"). Frames beyond the end of the
+// foot are carried into the feet.
+func ParseFeetFrames(str string, gauge FilmGauge) (FeetFrames, error) {
+	match := FeetFramesRegex.FindStringSubmatch(str)
+	if match == nil {
+		return FeetFrames{}, errors.New("invalid feet+frames format")
+	}
+	feet, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return FeetFrames{}, err
+	}
+	frames, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return FeetFrames{}, err
+	}
+	return FeetFramesFromFrame(FeetFrames{feet, frames, gauge}, gauge), nil
+}
+
+// Frame gets the frame index for this feet+frames value
+func (f FeetFrames) Frame() int64 {
+	return f.Gauge.footFrame(f.Feet) + f.Frames
+}
+
+// Add adds another framer instance to this feet+frames value
+func (f FeetFrames) Add(other Framer) FeetFrames {
+	return FeetFramesFromFrame(Frame(f.Frame()+other.Frame()), f.Gauge)
+}
+
+// String creates a string representation of the feet+frames value (ie. "1234+07")
+func (f FeetFrames) String() string {
+	return fmt.Sprintf("%d+%02d", f.Feet, f.Frames)
+}
@@ -0,0 +1,77 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeetFramesFromFrame(t *testing.T) {
+	type testCase struct {
+		gauge timecode.FilmGauge
+		frame int64
+		str   string
+	}
+	cases := []testCase{
+		{timecode.Gauge_35mm_4Perf, 0, "0+00"},
+		{timecode.Gauge_35mm_4Perf, 15, "0+15"},
+		{timecode.Gauge_35mm_4Perf, 16, "1+00"},
+		{timecode.Gauge_35mm_4Perf, 19751, "1234+07"},
+		{timecode.Gauge_35mm_4Perf, -1, "-1+15"},
+		{timecode.Gauge_16mm, 39, "0+39"},
+		{timecode.Gauge_16mm, 40, "1+00"},
+		{timecode.Gauge_35mm_2Perf, 33, "1+01"},
+
+		// 3-perf has a cycle of 3 feet, with 22, 21 and 21 frames
+		{timecode.Gauge_35mm_3Perf, 21, "0+21"},
+		{timecode.Gauge_35mm_3Perf, 22, "1+00"},
+		{timecode.Gauge_35mm_3Perf, 42, "1+20"},
+		{timecode.Gauge_35mm_3Perf, 43, "2+00"},
+		{timecode.Gauge_35mm_3Perf, 63, "2+20"},
+		{timecode.Gauge_35mm_3Perf, 64, "3+00"},
+	}
+	for _, tc := range cases {
+		ff := timecode.FeetFramesFromFrame(timecode.Frame(tc.frame), tc.gauge)
+		require.Equal(t, tc.str, ff.String(), "%s frame %d", tc.gauge.Name, tc.frame)
+		require.Equal(t, tc.frame, ff.Frame(), "%s frame %d", tc.gauge.Name, tc.frame)
+	}
+}
+
+func TestParseFeetFrames(t *testing.T) {
+	ff, err := timecode.ParseFeetFrames("1234+07", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	require.Equal(t, timecode.FeetFrames{1234, 7, timecode.Gauge_35mm_4Perf}, ff)
+	require.Equal(t, int64(19751), ff.Frame())
+
+	// Frames beyond the end of the foot are carried
+	ff, err = timecode.ParseFeetFrames("10+20", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	require.Equal(t, "11+04", ff.String())
+
+	for _, str := range []string{"", "12", "12+", "+12", "12-04", "1.5+04"} {
+		_, err := timecode.ParseFeetFrames(str, timecode.Gauge_35mm_4Perf)
+		require.Error(t, err, str)
+	}
+}
+
+func TestFeetFrames_Timecode(t *testing.T) {
+	// One hour at 24 frames per second is 5400 feet of 35mm 4-perf film
+	tc := timecode.MustParse("01:00:00:00", timecode.Rate_24)
+	ff := timecode.FeetFramesFromFrame(tc, timecode.Gauge_35mm_4Perf)
+	require.Equal(t, "5400+00", ff.String())
+	require.Equal(t, "01:00:00:00", timecode.FromFrame(ff.Frame(), timecode.Rate_24, false).String())
+
+	require.Equal(t, "01:00:00:20", tc.Add(timecode.FeetFrames{1, 4, timecode.Gauge_35mm_4Perf}).String())
+	require.Equal(t, "5401+04", ff.Add(timecode.Frame(20)).String())
+}
+
+func TestFeetFrames_ZeroGauge(t *testing.T) {
+	// Without a gauge, all the frames are in the first foot
+	require.Equal(t, int64(0), timecode.FeetFrames{}.Frame())
+	require.Equal(t, int64(7), timecode.FeetFrames{Feet: 3, Frames: 7}.Frame())
+	require.Equal(t, "0+20", timecode.FeetFramesFromFrame(timecode.Frame(20), timecode.FilmGauge{}).String())
+	require.Equal(t, "0+05", timecode.FeetFrames{}.Add(timecode.Frame(5)).String())
+	require.Equal(t, int64(0), timecode.KeyKode{}.Frame())
+	require.Equal(t, int64(5), timecode.KeyKode{}.Add(timecode.Frame(5)).Frame())
+}
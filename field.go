@@ -0,0 +1,107 @@
+package timecode
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// FieldTimecodeRegex is the pattern for a timecode with an interlaced field. The field is either given as
+// a suffix (ie. "01:00:00:12.2"), or marked using a period or comma as the final separator for the second
+// field of non-drop frame and drop frame timecodes respectively (ie. "01:00:00.12" or "01:00:00,12").
+var FieldTimecodeRegex = regexp.MustCompile(`^(\d\d)(:|;)(\d\d)(:|;)(\d\d)(:|;|\.|,)(\d+)(?:\.([12]))?$`)
+
+// ParseField parses a timecode with an interlaced field from a string, and treats it using the provided
+// frame rate value. Timecodes without a field suffix or field mark are in the first field.
+func ParseField(timecode string, rate Rate) (*Timecode, error) {
+	match := FieldTimecodeRegex.FindStringSubmatch(timecode)
+	if match == nil {
+		return nil, errors.New("invalid timecode format")
+	}
+	field := 1
+	if match[6] == "." || match[6] == "," {
+		if match[8] != "" {
+			return nil, errors.New("timecode has both a field mark and a field suffix")
+		}
+		field = 2
+	} else if match[8] == "2" {
+		field = 2
+	}
+
+	// Parse it as a regular timecode, with drop frame separators in place of the field marks
+	sep := match[6]
+	switch sep {
+	case ".":
+		sep = ":"
+	case ",":
+		sep = ";"
+	}
+	tc, err := Parse(match[1]+match[2]+match[3]+match[4]+match[5]+sep+match[7], rate)
+	if err != nil {
+		return nil, err
+	}
+	return tc.WithField(field), nil
+}
+
+// Field gets the interlaced field of the timecode, which is 1 or 2, or 0 if the timecode has no field
+func (t *Timecode) Field() int {
+	return t.field
+}
+
+// WithField creates a copy of this timecode in the given interlaced field (1 or 2). A field of zero removes
+// the field. Fields and sub-frames are exclusive, so any sub-frames are removed.
+func (t *Timecode) WithField(field int) *Timecode {
+	tc := FromFrame(t.frame, t.rate, t.dropFrame)
+	if field == 1 || field == 2 {
+		tc.field = field
+	}
+	return tc
+}
+
+// FieldCount gets the number of fields before this timecode. Timecodes without a field are in the first field.
+func (t *Timecode) FieldCount() int64 {
+	if t.field == 2 {
+		return t.frame*2 + 1
+	}
+	return t.frame * 2
+}
+
+// AddFields adds interlaced fields to this timecode, carrying them into the frames
+func (t *Timecode) AddFields(fields int64) *Timecode {
+	return FromFieldCount(t.FieldCount()+fields, t.rate, t.dropFrame)
+}
+
+// FieldMarkString creates a string representation of the timecode that marks the second field using a
+// period or comma as the final separator, for non-drop frame and drop frame timecodes respectively. This is
+// the form used by String, and it's understood by both Parse and ParseField.
+func (t *Timecode) FieldMarkString() string {
+	str := t.WithField(0).String()
+	if t.field != 2 {
+		return str
+	}
+	mark := "."
+	if t.dropFrame {
+		mark = ","
+	}
+	i := strings.LastIndexAny(str, ":;")
+	return str[:i] + mark + str[i+1:]
+}
+
+// FromFieldCount creates a timecode from a number of interlaced fields
+func FromFieldCount(fields int64, rate Rate, dropFrame bool) *Timecode {
+	frame := NewRational(fields, 2).Floor()
+	tc := FromFrame(frame, rate, dropFrame)
+	tc.field = int(fields-frame*2) + 1
+	return tc
+}
+
+// FieldRate gets the rate of the interlaced fields for this frame rate, which is twice the frame rate
+func (r Rate) FieldRate() Rate {
+	return Rate{
+		Str:     formatRate(r.Num*2, r.Den),
+		Nominal: r.Nominal * 2,
+		Drop:    r.Drop * 2,
+		Num:     r.Num * 2,
+		Den:     r.Den,
+	}.Canonical()
+}
@@ -0,0 +1,102 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseField(t *testing.T) {
+	type testCase struct {
+		rate  timecode.Rate
+		str   string
+		field int
+		frame int64
+	}
+	cases := map[string]testCase{
+		"01:00:00:12":   {timecode.Rate_25, "01:00:00:12", 1, 90012},
+		"01:00:00:12.1": {timecode.Rate_25, "01:00:00:12", 1, 90012},
+		"01:00:00:12.2": {timecode.Rate_25, "01:00:00.12", 2, 90012},
+		"01:00:00.12":   {timecode.Rate_25, "01:00:00.12", 2, 90012},
+		"00:01:00;02.2": {timecode.Rate_29_97, "00:01:00,02", 2, 1800},
+		"00:01:00,02":   {timecode.Rate_29_97, "00:01:00,02", 2, 1800},
+	}
+	for str, tc := range cases {
+		parsed, err := timecode.ParseField(str, tc.rate)
+		require.NoError(t, err, str)
+		require.Equal(t, tc.str, parsed.String(), str)
+		require.Equal(t, tc.field, parsed.Field(), str)
+		require.Equal(t, tc.frame, parsed.Frame(), str)
+	}
+
+	for _, str := range []string{"01:00:00:12.3", "01:00:00.12.2", "01:00.00:12"} {
+		_, err := timecode.ParseField(str, timecode.Rate_25)
+		require.Error(t, err, str)
+	}
+}
+
+func TestTimecode_FieldRoundTrip(t *testing.T) {
+	for _, tc := range []*timecode.Timecode{
+		timecode.MustParse("01:00:00:12", timecode.Rate_25).WithField(1),
+		timecode.MustParse("01:00:00:12", timecode.Rate_25).WithField(2),
+		timecode.MustParse("00:01:00;02", timecode.Rate_29_97).WithField(2),
+	} {
+		parsed, err := timecode.Parse(tc.String(), tc.Rate())
+		require.NoError(t, err, tc.String())
+		require.True(t, tc.Equals(parsed), tc.String())
+		require.Zero(t, parsed.SubframeResolution(), tc.String())
+
+		parsed, err = timecode.ParseField(tc.String(), tc.Rate())
+		require.NoError(t, err, tc.String())
+		require.Equal(t, tc.Field(), parsed.Field(), tc.String())
+		require.True(t, tc.Equals(parsed), tc.String())
+	}
+
+	// Parse treats a one-digit suffix as sub-frames, and a field mark as the second field
+	tc, err := timecode.Parse("01:00:00.12", timecode.Rate_25)
+	require.NoError(t, err)
+	require.Equal(t, 2, tc.Field())
+	_, err = timecode.Parse("01:00:00.12.2", timecode.Rate_25)
+	require.Error(t, err)
+}
+
+func TestTimecode_FieldMarkString(t *testing.T) {
+	require.Equal(t, "01:00:00:12", timecode.MustParse("01:00:00:12", timecode.Rate_25).WithField(1).FieldMarkString())
+	require.Equal(t, "01:00:00.12", timecode.MustParse("01:00:00:12", timecode.Rate_25).WithField(2).FieldMarkString())
+	require.Equal(t, "00:01:00,02", timecode.MustParse("00:01:00;02", timecode.Rate_29_97).WithField(2).FieldMarkString())
+}
+
+func TestTimecode_Fields(t *testing.T) {
+	t.Run("field counts", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:01:00", timecode.Rate_25)
+		require.Equal(t, int64(50), tc.FieldCount())
+		require.Equal(t, int64(51), tc.WithField(2).FieldCount())
+		require.Equal(t, "00:00:01.00", timecode.FromFieldCount(51, timecode.Rate_25, false).String())
+		require.Equal(t, "00:00:01:01", timecode.FromFieldCount(52, timecode.Rate_25, false).String())
+	})
+	t.Run("add fields", func(t *testing.T) {
+		tc, err := timecode.ParseField("00:00:59;29.2", timecode.Rate_29_97)
+		require.NoError(t, err)
+		require.Equal(t, "00:01:00;02", tc.AddFields(1).String())
+		require.Equal(t, "00:00:59;29", tc.AddFields(-1).String())
+		require.Equal(t, "00:00:59,29", tc.AddFrames(0).String())
+	})
+	t.Run("seconds", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:01:00", timecode.Rate_25).WithField(2)
+		require.Equal(t, timecode.NewRational(51, 50), tc.Seconds())
+		require.False(t, tc.Equals(tc.WithField(1)))
+		require.True(t, tc.Equals(timecode.FromFieldCount(51, timecode.Rate_25, false)))
+	})
+	t.Run("fields and sub-frames are exclusive", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:01:00.50", timecode.Rate_25).WithField(2)
+		require.Equal(t, "00:00:01.00", tc.String())
+		require.Equal(t, "00:00:01:00.10", tc.WithSubframe(10, 100).String())
+	})
+}
+
+func TestRate_FieldRate(t *testing.T) {
+	require.Equal(t, timecode.Rate_59_94, timecode.Rate_29_97.FieldRate())
+	require.Equal(t, timecode.Rate_50, timecode.Rate_25.FieldRate())
+	require.Equal(t, timecode.Rate_60, timecode.Rate_30.FieldRate())
+}
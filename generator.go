@@ -0,0 +1,201 @@
+package timecode
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time that drives a Generator. Tests can provide a fake implementation
+// to control exactly when frames are generated.
+type Clock interface {
+	// Now gets the current time
+	Now() time.Time
+	// At returns a channel that receives the time once the clock reaches t
+	At(t time.Time) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the system's wall clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) At(t time.Time) <-chan time.Time {
+	return time.After(time.Until(t))
+}
+
+// Generator is a running source of timecodes. While it is running, it sends each successive
+// timecode on its channel at the exact cadence of the rate. The cadence is derived from the
+// elapsed time rather than accumulated, so it never drifts from the clock.
+//
+// In free-run mode the generator counts from the last value it was jammed to. In time-of-day
+// mode it follows the time of day of its clock instead.
+type Generator struct {
+	// C is the channel on which the timecodes are delivered. If the receiver falls behind,
+	// the frames it missed are skipped so that the timecodes remain aligned to the clock.
+	C <-chan *Timecode
+
+	c         chan *Timecode
+	rate      Rate
+	dropFrame bool
+	clock     Clock
+
+	mu         sync.Mutex
+	timeOfDay  bool
+	startFrame int64
+	startTime  time.Time
+	running    bool
+	wake       chan struct{}
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewGenerator creates a stopped generator at frame zero. If clock is nil, the system clock is used.
+func NewGenerator(rate Rate, dropFrame bool, clock Clock) *Generator {
+	if clock == nil {
+		clock = SystemClock
+	}
+	c := make(chan *Timecode)
+	return &Generator{
+		C:         c,
+		c:         c,
+		rate:      rate,
+		dropFrame: dropFrame,
+		clock:     clock,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Start starts generating timecodes, beginning with the current value. Starting a generator
+// that is already running has no effect.
+func (g *Generator) Start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running {
+		return
+	}
+	g.startTime = g.clock.Now()
+	g.running = true
+	select {
+	case <-g.wake:
+	default:
+	}
+	g.stop = make(chan struct{})
+	g.done = make(chan struct{})
+	go g.run(g.stop, g.done)
+}
+
+// Stop stops generating timecodes and holds the current value. Stopping a generator that is
+// not running has no effect.
+func (g *Generator) Stop() {
+	g.mu.Lock()
+	if !g.running {
+		g.mu.Unlock()
+		return
+	}
+	g.startFrame = g.frameAt(g.clock.Now())
+	g.running = false
+	stop, done := g.stop, g.done
+	g.mu.Unlock()
+
+	close(stop)
+	<-done
+}
+
+// Jam sets the current value of the generator, and switches it to free-run mode. If the generator
+// is running, it continues counting from the new value.
+func (g *Generator) Jam(tc *Timecode) {
+	g.mu.Lock()
+	g.timeOfDay = false
+	g.startFrame = tc.Frame()
+	g.startTime = g.clock.Now()
+	g.mu.Unlock()
+	g.notify()
+}
+
+// SetTimeOfDay switches the generator between time-of-day mode and free-run mode. When leaving
+// time-of-day mode, the generator continues counting from the current time of day.
+func (g *Generator) SetTimeOfDay(enabled bool) {
+	g.mu.Lock()
+	now := g.clock.Now()
+	if g.timeOfDay && !enabled {
+		g.startFrame = g.frameAt(now)
+		g.startTime = now
+	}
+	g.timeOfDay = enabled
+	g.mu.Unlock()
+	g.notify()
+}
+
+// Current gets the current value of the generator
+func (g *Generator) Current() *Timecode {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return FromFrame(g.frameAt(g.clock.Now()), g.rate, g.dropFrame)
+}
+
+// notify wakes up the running generator so that it picks up a change of value
+func (g *Generator) notify() {
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// frameAt gets the frame index of the generator at the given time. The lock must be held.
+func (g *Generator) frameAt(now time.Time) int64 {
+	if !g.running {
+		return g.startFrame
+	}
+	if g.timeOfDay {
+		return timeOfDayFrame(now, g.rate, g.dropFrame)
+	}
+	elapsed := now.Sub(g.startTime)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return g.startFrame + g.rate.Frames(durationSeconds(elapsed)).Floor()
+}
+
+// nextFrameTime gets the time at which the frame after the given one begins. The lock must be held.
+func (g *Generator) nextFrameTime(now time.Time, frame int64) time.Time {
+	if g.timeOfDay {
+		return nextTimeOfDayFrameTime(now, frame, g.rate, g.dropFrame)
+	}
+	return g.startTime.Add(ceilDuration(g.rate.Seconds(frame + 1 - g.startFrame)))
+}
+
+func (g *Generator) run(stop, done chan struct{}) {
+	defer close(done)
+	var last int64
+	emit := true
+	for {
+		g.mu.Lock()
+		now := g.clock.Now()
+		frame := g.frameAt(now)
+		next := g.nextFrameTime(now, frame)
+		g.mu.Unlock()
+
+		// Send the timecode if the frame changed
+		if emit || frame != last {
+			select {
+			case g.c <- FromFrame(frame, g.rate, g.dropFrame):
+			case <-stop:
+				return
+			}
+			last, emit = frame, false
+		}
+
+		// Wait for the next frame, or for the value to change
+		select {
+		case <-g.clock.At(next):
+		case <-g.wake:
+			emit = true
+		case <-stop:
+			return
+		}
+	}
+}
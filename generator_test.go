@@ -0,0 +1,151 @@
+package timecode_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock that only moves forward when it is advanced by the test
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) At(t time.Time) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if !t.After(c.now) {
+		ch <- c.now
+	} else {
+		c.waiters = append(c.waiters, fakeWaiter{t, ch})
+	}
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.at.After(c.now) {
+			w.ch <- c.now
+		} else {
+			waiters = append(waiters, w)
+		}
+	}
+	c.waiters = waiters
+}
+
+func receive(t *testing.T, gen *timecode.Generator) string {
+	select {
+	case tc := <-gen.C:
+		return tc.String()
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a timecode")
+		return ""
+	}
+}
+
+func TestGenerator_FreeRun(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	gen := timecode.NewGenerator(timecode.Rate_29_97, true, clock)
+	gen.Jam(timecode.MustParse("00:00:59;28", timecode.Rate_29_97))
+	gen.Start()
+	defer gen.Stop()
+
+	require.Equal(t, "00:00:59;28", receive(t, gen))
+
+	// Each frame of 29.97 lasts 1001/30000 seconds, which isn't a whole number of nanoseconds
+	clock.Advance(33366666 * time.Nanosecond)
+	clock.Advance(time.Nanosecond)
+	require.Equal(t, "00:00:59;29", receive(t, gen))
+	clock.Advance(33366667 * time.Nanosecond)
+	require.Equal(t, "00:01:00;02", receive(t, gen))
+
+	// Frames that elapse without being received are skipped
+	clock.Advance(1001 * time.Millisecond)
+	require.Equal(t, "00:01:01;02", receive(t, gen))
+}
+
+func TestGenerator_NoDrift(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	gen := timecode.NewGenerator(timecode.Rate_23_976, false, clock)
+	gen.Start()
+	defer gen.Stop()
+
+	require.Equal(t, "00:00:00:00", receive(t, gen))
+	start := clock.Now()
+	for i := int64(1); i <= 24*60; i++ {
+		// Advance to the first nanosecond of each frame
+		at := start.Add(time.Duration((i*1001*int64(time.Second) + 23999) / 24000))
+		clock.Advance(at.Sub(clock.Now()))
+		require.Equal(t, timecode.FromFrame(i, timecode.Rate_23_976, false).String(), receive(t, gen))
+	}
+
+	// 1440 frames of 23.976 last exactly 60.06 seconds
+	require.Equal(t, 60060*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestGenerator_StopAndJam(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	gen := timecode.NewGenerator(timecode.Rate_25, false, clock)
+	gen.Jam(timecode.MustParse("10:00:00:00", timecode.Rate_25))
+	gen.Start()
+	require.Equal(t, "10:00:00:00", receive(t, gen))
+	clock.Advance(40 * time.Millisecond)
+	require.Equal(t, "10:00:00:01", receive(t, gen))
+
+	// Stopping holds the current value
+	gen.Stop()
+	clock.Advance(time.Second)
+	require.Equal(t, "10:00:00:01", gen.Current().String())
+
+	// Restarting continues from the held value
+	gen.Start()
+	require.Equal(t, "10:00:00:01", receive(t, gen))
+	clock.Advance(40 * time.Millisecond)
+	require.Equal(t, "10:00:00:02", receive(t, gen))
+
+	// Jamming while running continues from the new value
+	gen.Jam(timecode.MustParse("20:00:00:00", timecode.Rate_25))
+	require.Equal(t, "20:00:00:00", receive(t, gen))
+	clock.Advance(40 * time.Millisecond)
+	require.Equal(t, "20:00:00:01", receive(t, gen))
+	gen.Stop()
+}
+
+func TestGenerator_TimeOfDay(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 14, 30, 15, 500_000_000, time.UTC)}
+	gen := timecode.NewGenerator(timecode.Rate_25, false, clock)
+	gen.SetTimeOfDay(true)
+	gen.Start()
+	defer gen.Stop()
+
+	require.Equal(t, "14:30:15:12", receive(t, gen))
+	clock.Advance(20 * time.Millisecond)
+	require.Equal(t, "14:30:15:13", receive(t, gen))
+
+	// The day rolls over at midnight
+	clock.Advance(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC).Sub(clock.Now()) - 10*time.Millisecond)
+	require.Equal(t, "23:59:59:24", receive(t, gen))
+	clock.Advance(10 * time.Millisecond)
+	require.Equal(t, "00:00:00:00", receive(t, gen))
+}
@@ -0,0 +1,117 @@
+package timecode
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// inputSeparatorRegex matches the separators between the fields of a typed timecode
+var inputSeparatorRegex = regexp.MustCompile(`[:;.,]`)
+
+// Interpret interprets a timecode typed into a timecode entry field the way editing applications do,
+// given the current value of the field:
+//
+//   - digits are aligned to the right, so "1000" is 00:00:10:00
+//   - fields can be separated with any of : ; . or , and empty fields keep their current value, so "..12"
+//     only replaces the frames. Missing fields on the left keep their current value if the input starts
+//     with a separator, and are zero otherwise.
+//   - a leading + or - offsets the current value by the typed duration, so "+110" is 1 second and 10
+//     frames later
+//
+// Fields that overflow carry into the next field, so "90" is 00:00:03:18 at 24 fps. The result has the
+// rate and drop frame mode of the current value. Empty input leaves the current value unchanged, and an
+// error is returned if the result would be before zero.
+func Interpret(input string, current *Timecode) (*Timecode, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return current, nil
+	}
+
+	// Check for a relative entry
+	sign := int64(0)
+	switch input[0] {
+	case '+':
+		sign, input = 1, input[1:]
+	case '-':
+		sign, input = -1, input[1:]
+	}
+	fields, err := inputFields(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var tc *Timecode
+	if sign != 0 {
+		// Offset by the typed duration, counted in nominal frames
+		var c Components
+		for i, field := range fields {
+			if field != nil {
+				*c.field(i) = *field
+			}
+		}
+		totalFrames := ((c.Hours*60+c.Minutes)*60+c.Seconds)*int64(current.rate.Nominal) + c.Frames
+		tc = current.AddFrames(sign * totalFrames)
+	} else {
+		// Replace the typed fields of the current value
+		c := current.Components()
+		for i, field := range fields {
+			if field != nil {
+				*c.field(i) = *field
+			}
+		}
+		tc = FromComponents(c, current.rate, current.dropFrame)
+	}
+	if tc.frame < 0 {
+		return nil, errors.New("timecode is before zero")
+	}
+	return tc, nil
+}
+
+// inputFields splits a typed timecode into hours, minutes, seconds and frames. Fields that keep their
+// current value are nil.
+func inputFields(input string) ([4]*int64, error) {
+	var fields [4]*int64
+	parts := inputSeparatorRegex.Split(input, -1)
+
+	// Digits without separators fill the fields in pairs from the right
+	if len(parts) == 1 {
+		if input == "" || len(input) > 8 {
+			return fields, errors.New("invalid timecode entry")
+		}
+		input = strings.Repeat("0", 8-len(input)) + input
+		for i := range fields {
+			parts = append(parts, input[i*2:i*2+2])
+		}
+		parts = parts[1:]
+	}
+	if len(parts) > len(fields) {
+		return fields, errors.New("timecode entry has too many fields")
+	}
+
+	// Missing fields on the left are zero, unless the input starts with a separator
+	offset := len(fields) - len(parts)
+	if parts[0] != "" {
+		for i := 0; i < offset; i++ {
+			fields[i] = new(int64)
+		}
+	}
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return fields, errors.New("invalid timecode entry")
+		}
+		value := int64(n)
+		fields[offset+i] = &value
+	}
+	return fields, nil
+}
+
+// field gets a pointer to the component at an index, from hours to frames
+func (c *Components) field(i int) *int64 {
+	return [...]*int64{&c.Hours, &c.Minutes, &c.Seconds, &c.Frames}[i]
+}
@@ -0,0 +1,69 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpret(t *testing.T) {
+	current := timecode.MustParse("01:02:03:04", timecode.Rate_24)
+	cases := map[string]string{
+		"":            "01:02:03:04",
+		"1000":        "00:00:10:00",
+		"1":           "00:00:00:01",
+		"90":          "00:00:03:18",
+		"1000000":     "01:00:00:00",
+		"23595923":    "23:59:59:23",
+		" 1000 ":      "00:00:10:00",
+		"..12":        "01:02:03:12",
+		"::12":        "01:02:03:12",
+		".5.":         "01:02:05:04",
+		"1..":         "00:01:03:04",
+		"1.2":         "00:00:01:02",
+		"1:2:3:4":     "01:02:03:04",
+		"10:00:00:00": "10:00:00:00",
+		".00.00.00":   "01:00:00:00",
+		"+110":        "01:02:04:14",
+		"+1.10":       "01:02:04:14",
+		"-4":          "01:02:03:00",
+		"-1000":       "01:01:53:04",
+		"+..1":        "01:02:03:05",
+		"+90":         "01:02:06:22",
+	}
+	for input, expected := range cases {
+		tc, err := timecode.Interpret(input, current)
+		require.NoError(t, err, input)
+		require.Equal(t, expected, tc.String(), input)
+	}
+}
+
+func TestInterpret_DropFrame(t *testing.T) {
+	// Relative entries count nominal frames, and dropped timecodes round up to the next valid frame
+	current := timecode.MustParse("00:00:59;29", timecode.Rate_29_97)
+	tc, err := timecode.Interpret("+1", current)
+	require.NoError(t, err)
+	require.Equal(t, "00:01:00;02", tc.String())
+	tc, err = timecode.Interpret("..00", tc)
+	require.NoError(t, err)
+	require.Equal(t, "00:01:00;02", tc.String())
+	tc, err = timecode.Interpret("+100", current)
+	require.NoError(t, err)
+	require.Equal(t, "00:01:01;01", tc.String())
+}
+
+func TestInterpret_Invalid(t *testing.T) {
+	current := timecode.MustParse("00:00:01:00", timecode.Rate_25)
+	for _, input := range []string{
+		"+",
+		"123456789",
+		"1:2:3:4:5",
+		"12a",
+		"1.-2",
+		"-200",
+	} {
+		_, err := timecode.Interpret(input, current)
+		require.Error(t, err, input)
+	}
+}
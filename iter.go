@@ -0,0 +1,18 @@
+//go:build go1.23
+
+package timecode
+
+import "iter"
+
+// Timecodes gets a sequence of the whole frames from start up to, but not including, end. The timecodes
+// have the rate and drop frame mode of start.
+func Timecodes(start *Timecode, end Framer) iter.Seq[*Timecode] {
+	return func(yield func(*Timecode) bool) {
+		it := NewIterator(start, end)
+		for it.Next() {
+			if !yield(it.Timecode()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimecodes(t *testing.T) {
+	start := timecode.MustParse("00:09:59;28", timecode.Rate_29_97)
+	var tcs []string
+	for tc := range timecode.Timecodes(start, start.AddFrames(4)) {
+		tcs = append(tcs, tc.String())
+	}
+	require.Equal(t, []string{"00:09:59;28", "00:09:59;29", "00:10:00;00", "00:10:00;01"}, tcs)
+
+	// Stopping early
+	tcs = nil
+	for tc := range timecode.Timecodes(start, timecode.Frame(1<<40)) {
+		if len(tcs) == 2 {
+			break
+		}
+		tcs = append(tcs, tc.String())
+	}
+	require.Equal(t, []string{"00:09:59;28", "00:09:59;29"}, tcs)
+}
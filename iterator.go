@@ -0,0 +1,74 @@
+package timecode
+
+// Iterator steps through consecutive timecodes. Each step increments the components of the previous
+// timecode directly, rather than deriving them from the frame count, which makes formatting each
+// timecode cheap.
+type Iterator struct {
+	next, end  int64
+	rate       Rate
+	dropFrame  bool
+	components Components
+	current    *Timecode
+}
+
+// NewIterator creates an iterator over the whole frames from start up to, but not including, end. The
+// timecodes have the rate and drop frame mode of start.
+func NewIterator(start *Timecode, end Framer) *Iterator {
+	return &Iterator{
+		next:      start.frame,
+		end:       end.Frame(),
+		rate:      start.rate,
+		dropFrame: start.dropFrame,
+	}
+}
+
+// Next advances to the next timecode, and reports whether there is one
+func (it *Iterator) Next() bool {
+	if it.next >= it.end {
+		it.current = nil
+		return false
+	}
+	// Negative frames don't count up like positive ones, so their components are derived from the frame
+	// count until the iterator reaches zero
+	if it.current != nil && it.next > 0 {
+		it.components.increment(it.rate, it.dropFrame)
+	} else {
+		it.components = FromFrame(it.next, it.rate, it.dropFrame).Components()
+	}
+	components := it.components
+	it.current = &Timecode{
+		frame:      it.next,
+		rate:       it.rate,
+		dropFrame:  it.dropFrame,
+		components: &components,
+	}
+	it.next++
+	return true
+}
+
+// Timecode gets the current timecode, or nil if Next hasn't been called or there are no more timecodes
+func (it *Iterator) Timecode() *Timecode {
+	return it.current
+}
+
+// increment advances the components by a single frame, skipping the dropped frames of drop frame timecodes
+func (c *Components) increment(rate Rate, dropFrame bool) {
+	c.Frames++
+	if c.Frames < int64(rate.Nominal) {
+		return
+	}
+	c.Frames = 0
+	c.Seconds++
+	if c.Seconds < 60 {
+		return
+	}
+	c.Seconds = 0
+	c.Minutes++
+	if c.Minutes == 60 {
+		c.Minutes = 0
+		c.Hours++
+	}
+	if dropFrame && c.Minutes%10 > 0 {
+		c.Frames = int64(rate.Drop)
+	}
+}
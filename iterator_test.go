@@ -0,0 +1,79 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	it := timecode.NewIterator(
+		timecode.MustParse("00:00:59;28", timecode.Rate_29_97),
+		timecode.MustParse("00:01:00;04", timecode.Rate_29_97),
+	)
+	require.Nil(t, it.Timecode())
+	var tcs []string
+	for it.Next() {
+		tcs = append(tcs, it.Timecode().String())
+	}
+	require.Equal(t, []string{"00:00:59;28", "00:00:59;29", "00:01:00;02", "00:01:00;03"}, tcs)
+	require.Nil(t, it.Timecode())
+	require.False(t, it.Next())
+
+	// Empty ranges have no timecodes
+	start := timecode.MustParse("01:00:00:00", timecode.Rate_24)
+	require.False(t, timecode.NewIterator(start, start).Next())
+	require.False(t, timecode.NewIterator(start, timecode.Frame(0)).Next())
+}
+
+func TestIterator_MatchesFrames(t *testing.T) {
+	cases := []struct {
+		start     string
+		rate      timecode.Rate
+		dropFrame bool
+	}{
+		{"00:00:00:00", timecode.Rate_24, false},
+		{"00:59:30:00", timecode.Rate_25, false},
+		{"00:08:59;00", timecode.Rate_29_97, true},
+		{"00:08:59:00", timecode.Rate_29_97, false},
+		{"23:58:00;00", timecode.Rate_59_94, true},
+	}
+	for _, c := range cases {
+		start := timecode.MustParse(c.start, c.rate)
+		require.Equal(t, c.dropFrame, start.DropFrame())
+		it := timecode.NewIterator(start, start.AddFrames(20000))
+		for frame := start.Frame(); it.Next(); frame++ {
+			tc := it.Timecode()
+			expected := timecode.FromFrame(frame, c.rate, c.dropFrame)
+			require.Equal(t, frame, tc.Frame())
+			require.Equal(t, expected.Components(), tc.Components())
+			require.Equal(t, expected.String(), tc.String())
+		}
+	}
+}
+
+func TestIterator_Negative(t *testing.T) {
+	// Negative frames are labelled the same way as FromFrame, through zero and beyond
+	for _, df := range []bool{false, true} {
+		rate := timecode.Rate_24
+		if df {
+			rate = timecode.Rate_29_97
+		}
+		start := timecode.FromFrame(-30, rate, df)
+		it := timecode.NewIterator(start, timecode.Frame(30))
+		for frame := start.Frame(); it.Next(); frame++ {
+			expected := timecode.FromFrame(frame, rate, df)
+			require.Equal(t, expected.Components(), it.Timecode().Components(), frame)
+			require.Equal(t, expected.String(), it.Timecode().String(), frame)
+		}
+	}
+}
+
+func TestIterator_Subframes(t *testing.T) {
+	// Iterators step through whole frames
+	start := timecode.MustParse("01:00:00:00.50", timecode.Rate_24)
+	it := timecode.NewIterator(start, start.AddFrames(2))
+	require.True(t, it.Next())
+	require.Equal(t, "01:00:00:00", it.Timecode().String())
+}
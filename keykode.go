@@ -0,0 +1,93 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// KeyKodeRegex is the pattern for a valid KeyKode (ie. "KJ 23 1234 5678+12"). The spaces are optional.
+var KeyKodeRegex = regexp.MustCompile(`^([A-Z])([A-Z]{1,2}) ?(\d{2}) ?(\d{4}) ?(\d+)\+(\d+)$`)
+
+// KeyKode represents a machine readable edge code printed along a strip of film, which identifies the
+// roll of film and counts feet and frames along it
+type KeyKode struct {
+	// Manufacturer is the manufacturer code (ie. "K" for Kodak)
+	Manufacturer string
+	// Emulsion is the film type code of the emulsion (ie. "J")
+	Emulsion string
+	// Roll is the two-digit roll identification
+	Roll string
+	// Prefix is the four-digit prefix that completes the roll identification
+	Prefix string
+	// FeetFrames is the footage count and the frame offset from it
+	FeetFrames
+}
+
+// ParseKeyKode parses a KeyKode from a string (ie. "KJ 23 1234 5678+12"), on the given film gauge.
+// Frames beyond the end of the foot are carried into the feet.
+func ParseKeyKode(str string, gauge FilmGauge) (KeyKode, error) {
+	match := KeyKodeRegex.FindStringSubmatch(str)
+	if match == nil {
+		return KeyKode{}, errors.New("invalid KeyKode format")
+	}
+	feet, err := strconv.ParseInt(match[5], 10, 64)
+	if err != nil {
+		return KeyKode{}, err
+	}
+	frames, err := strconv.ParseInt(match[6], 10, 64)
+	if err != nil {
+		return KeyKode{}, err
+	}
+	return KeyKode{
+		Manufacturer: match[1],
+		Emulsion:     match[2],
+		Roll:         match[3],
+		Prefix:       match[4],
+		FeetFrames:   FeetFramesFromFrame(FeetFrames{feet, frames, gauge}, gauge),
+	}, nil
+}
+
+// String creates a string representation of the KeyKode (ie. "KJ 23 1234 5678+12")
+func (k KeyKode) String() string {
+	return fmt.Sprintf("%s%s %s %s %04d+%02d", k.Manufacturer, k.Emulsion, k.Roll, k.Prefix, k.Feet, k.Frames)
+}
+
+// SameRoll checks if this KeyKode identifies the same roll of film as another KeyKode
+func (k KeyKode) SameRoll(other KeyKode) bool {
+	return k.Manufacturer == other.Manufacturer &&
+		k.Emulsion == other.Emulsion &&
+		k.Roll == other.Roll &&
+		k.Prefix == other.Prefix
+}
+
+// Add adds another framer instance to this KeyKode, carrying frames into the feet
+func (k KeyKode) Add(other Framer) KeyKode {
+	k.FeetFrames = k.FeetFrames.Add(other)
+	return k
+}
+
+// Offset gets the number of frames from a zero-frame KeyKode to this KeyKode. Both of them must
+// be on the same roll of film.
+func (k KeyKode) Offset(zero KeyKode) (Frame, error) {
+	if !k.SameRoll(zero) {
+		return 0, fmt.Errorf("KeyKode %s is not on the same roll as %s", k.String(), zero.String())
+	}
+	return Frame(k.Frame() - zero.Frame()), nil
+}
+
+// Timecode gets the timecode of this KeyKode, given the KeyKode and timecode of a zero-frame
+// on the same roll of film
+func (k KeyKode) Timecode(zero KeyKode, zeroTimecode *Timecode) (*Timecode, error) {
+	offset, err := k.Offset(zero)
+	if err != nil {
+		return nil, err
+	}
+	return zeroTimecode.Add(offset), nil
+}
+
+// KeyKodeAt gets the KeyKode of a timecode, given the KeyKode and timecode of a zero-frame
+func KeyKodeAt(tc *Timecode, zero KeyKode, zeroTimecode *Timecode) KeyKode {
+	return zero.Add(Frame(tc.Frame() - zeroTimecode.Frame()))
+}
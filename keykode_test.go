@@ -0,0 +1,61 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyKode(t *testing.T) {
+	kk, err := timecode.ParseKeyKode("KJ 23 1234 5678+12", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	require.Equal(t, "K", kk.Manufacturer)
+	require.Equal(t, "J", kk.Emulsion)
+	require.Equal(t, "23", kk.Roll)
+	require.Equal(t, "1234", kk.Prefix)
+	require.Equal(t, int64(5678), kk.Feet)
+	require.Equal(t, int64(12), kk.Frames)
+	require.Equal(t, "KJ 23 1234 5678+12", kk.String())
+
+	// Spaces are optional, and frames beyond the end of the foot are carried
+	kk, err = timecode.ParseKeyKode("KJ2312340099+17", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	require.Equal(t, "KJ 23 1234 0100+01", kk.String())
+
+	for _, str := range []string{"", "KJ 23 1234 5678", "kj 23 1234 5678+12", "KJ 2 1234 5678+12"} {
+		_, err := timecode.ParseKeyKode(str, timecode.Gauge_35mm_4Perf)
+		require.Error(t, err, str)
+	}
+}
+
+func TestKeyKode_Arithmetic(t *testing.T) {
+	zero, err := timecode.ParseKeyKode("KJ 23 1234 5678+12", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+
+	kk := zero.Add(timecode.Frame(20))
+	require.Equal(t, "KJ 23 1234 5680+00", kk.String())
+
+	offset, err := kk.Offset(zero)
+	require.NoError(t, err)
+	require.Equal(t, timecode.Frame(20), offset)
+
+	other, err := timecode.ParseKeyKode("KJ 23 9999 5680+00", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	_, err = other.Offset(zero)
+	require.Error(t, err)
+}
+
+func TestKeyKode_Timecode(t *testing.T) {
+	zero, err := timecode.ParseKeyKode("KU 12 3456 1000+00", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	zeroTimecode := timecode.MustParse("01:00:00:00", timecode.Rate_24)
+
+	kk, err := timecode.ParseKeyKode("KU 12 3456 1090+00", timecode.Gauge_35mm_4Perf)
+	require.NoError(t, err)
+	tc, err := kk.Timecode(zero, zeroTimecode)
+	require.NoError(t, err)
+	require.Equal(t, "01:01:00:00", tc.String())
+
+	require.Equal(t, "KU 12 3456 1090+00", timecode.KeyKodeAt(tc, zero, zeroTimecode).String())
+}
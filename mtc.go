@@ -0,0 +1,169 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MTCRate is the frame rate code carried in MIDI Timecode (MTC) messages
+type MTCRate byte
+
+const (
+	MTCRate_24       MTCRate = 0
+	MTCRate_25       MTCRate = 1
+	MTCRate_29_97_DF MTCRate = 2
+	MTCRate_30       MTCRate = 3
+)
+
+// MTCRateFor gets the MTC rate code used to transmit timecodes at the given rate. MTC only carries
+// nominal frame counts, so pull-down rates are sent using the code of their nominal rate (ie. 23.976
+// is sent as 24, and 29.97 non-drop frame is sent as 30).
+func MTCRateFor(rate Rate, dropFrame bool) (MTCRate, error) {
+	switch rate.Nominal {
+	case 24:
+		return MTCRate_24, nil
+	case 25:
+		return MTCRate_25, nil
+	case 30:
+		if dropFrame && rate.Drop > 0 {
+			return MTCRate_29_97_DF, nil
+		}
+		return MTCRate_30, nil
+	}
+	return 0, fmt.Errorf("rate %s cannot be represented in MTC", rate.String())
+}
+
+// Rate gets the frame rate and drop frame flag for this MTC rate code
+func (r MTCRate) Rate() (Rate, bool) {
+	switch r & 0x03 {
+	case MTCRate_24:
+		return Rate_24, false
+	case MTCRate_25:
+		return Rate_25, false
+	case MTCRate_29_97_DF:
+		return Rate_29_97, true
+	default:
+		return Rate_30, false
+	}
+}
+
+// mtcComponents gets the rate code and components of a timecode, making sure they fit in MTC messages
+func (t *Timecode) mtcComponents() (MTCRate, Components, error) {
+	code, err := MTCRateFor(t.rate, t.dropFrame)
+	if err != nil {
+		return 0, Components{}, err
+	}
+	if t.frame < 0 {
+		return 0, Components{}, errors.New("negative timecodes cannot be represented in MTC")
+	}
+	components := t.Components()
+	if components.Hours > 23 {
+		return 0, Components{}, errors.New("timecodes beyond 23 hours cannot be represented in MTC")
+	}
+	return code, components, nil
+}
+
+// MTCQuarterFrames creates the 8 quarter-frame messages that transmit this timecode. The messages are
+// sent in order over the span of two frames, and each one is a 0xF1 status byte followed by a data byte.
+func (t *Timecode) MTCQuarterFrames() ([8][2]byte, error) {
+	var messages [8][2]byte
+	code, c, err := t.mtcComponents()
+	if err != nil {
+		return messages, err
+	}
+
+	// Split each component into a low and a high nibble
+	nibbles := [8]byte{
+		byte(c.Frames & 0x0F),
+		byte(c.Frames >> 4 & 0x01),
+		byte(c.Seconds & 0x0F),
+		byte(c.Seconds >> 4 & 0x03),
+		byte(c.Minutes & 0x0F),
+		byte(c.Minutes >> 4 & 0x03),
+		byte(c.Hours & 0x0F),
+		byte(c.Hours>>4&0x01) | byte(code)<<1,
+	}
+	for piece, nibble := range nibbles {
+		messages[piece] = [2]byte{0xF1, byte(piece)<<4 | nibble}
+	}
+	return messages, nil
+}
+
+// MTCFullFrame creates the full-frame SysEx message for this timecode, which is used to locate a receiver
+// to a new position without running the quarter-frame sequence.
+func (t *Timecode) MTCFullFrame() ([]byte, error) {
+	code, c, err := t.mtcComponents()
+	if err != nil {
+		return nil, err
+	}
+	return []byte{
+		0xF0, 0x7F, 0x7F, 0x01, 0x01,
+		byte(code)<<5 | byte(c.Hours),
+		byte(c.Minutes),
+		byte(c.Seconds),
+		byte(c.Frames),
+		0xF7,
+	}, nil
+}
+
+// MTCDecoder reassembles MTC quarter-frame and full-frame messages into timecodes. The zero value is
+// ready to use. A decoder is not safe for concurrent use.
+type MTCDecoder struct {
+	nibbles [8]byte
+	next    int
+}
+
+// Decode consumes a single MTC message. It returns a timecode once a full-frame message or a complete
+// sequence of 8 quarter-frames has been received, and nil otherwise. Quarter-frames that arrive out of
+// sequence discard the partially received timecode.
+//
+// A quarter-frame sequence describes the frame at which its first message was sent, so the returned
+// timecode is two frames behind the sender by the time the last message arrives.
+func (d *MTCDecoder) Decode(msg []byte) (*Timecode, error) {
+	// Full-frame SysEx message
+	if len(msg) == 10 && msg[0] == 0xF0 && msg[1] == 0x7F && msg[3] == 0x01 && msg[4] == 0x01 && msg[9] == 0xF7 {
+		d.next = 0
+		return mtcTimecode(MTCRate(msg[5]>>5), Components{
+			int64(msg[5] & 0x1F),
+			int64(msg[6]),
+			int64(msg[7]),
+			int64(msg[8]),
+		})
+	}
+
+	// Quarter-frame message
+	if len(msg) != 2 || msg[0] != 0xF1 {
+		return nil, errors.New("invalid MTC message")
+	}
+	piece := int(msg[1] >> 4 & 0x07)
+	if piece != d.next {
+		d.next = 0
+		if piece != 0 {
+			return nil, nil
+		}
+	}
+	d.nibbles[piece] = msg[1] & 0x0F
+	d.next++
+	if d.next < len(d.nibbles) {
+		return nil, nil
+	}
+	d.next = 0
+
+	// Combine the nibbles into the timecode
+	n := d.nibbles
+	return mtcTimecode(MTCRate(n[7]>>1), Components{
+		int64(n[7]&0x01)<<4 | int64(n[6]),
+		int64(n[5]&0x03)<<4 | int64(n[4]),
+		int64(n[3]&0x03)<<4 | int64(n[2]),
+		int64(n[1]&0x01)<<4 | int64(n[0]),
+	})
+}
+
+// mtcTimecode creates a timecode from the rate code and components received in MTC messages
+func mtcTimecode(code MTCRate, components Components) (*Timecode, error) {
+	rate, dropFrame := code.Rate()
+	if components.Hours > 23 || components.Minutes > 59 || components.Seconds > 59 || components.Frames >= int64(rate.Nominal) {
+		return nil, errors.New("invalid MTC timecode")
+	}
+	return FromComponents(components, rate, dropFrame), nil
+}
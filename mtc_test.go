@@ -0,0 +1,120 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMTCRateFor(t *testing.T) {
+	type testCase struct {
+		rate      timecode.Rate
+		dropFrame bool
+		code      timecode.MTCRate
+	}
+	cases := []testCase{
+		{timecode.Rate_23_976, false, timecode.MTCRate_24},
+		{timecode.Rate_24, false, timecode.MTCRate_24},
+		{timecode.Rate_25, false, timecode.MTCRate_25},
+		{timecode.Rate_29_97, true, timecode.MTCRate_29_97_DF},
+		{timecode.Rate_29_97, false, timecode.MTCRate_30},
+		{timecode.Rate_30, false, timecode.MTCRate_30},
+	}
+	for _, tc := range cases {
+		code, err := timecode.MTCRateFor(tc.rate, tc.dropFrame)
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+
+	_, err := timecode.MTCRateFor(timecode.Rate_59_94, true)
+	require.Error(t, err)
+}
+
+func TestMTCQuarterFrames(t *testing.T) {
+	tc := timecode.MustParse("01:37:52;16", timecode.Rate_29_97)
+	messages, err := tc.MTCQuarterFrames()
+	require.NoError(t, err)
+	require.Equal(t, [8][2]byte{
+		{0xF1, 0x00},
+		{0xF1, 0x11},
+		{0xF1, 0x24},
+		{0xF1, 0x33},
+		{0xF1, 0x45},
+		{0xF1, 0x52},
+		{0xF1, 0x61},
+		{0xF1, 0x74},
+	}, messages)
+}
+
+func TestMTCFullFrame(t *testing.T) {
+	tc := timecode.MustParse("01:37:52:16", timecode.Rate_25)
+	msg, err := tc.MTCFullFrame()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xF0, 0x7F, 0x7F, 0x01, 0x01, 0x21, 37, 52, 16, 0xF7}, msg)
+
+	_, err = timecode.MustParse("24:00:00:00", timecode.Rate_25).MTCFullFrame()
+	require.Error(t, err)
+}
+
+func TestMTCDecoder(t *testing.T) {
+	t.Run("quarter frames round trip", func(t *testing.T) {
+		cases := map[string]timecode.Rate{
+			"00:00:00:00": timecode.Rate_24,
+			"23:59:59:23": timecode.Rate_24,
+			"12:34:56:24": timecode.Rate_25,
+			"01:37:52;16": timecode.Rate_29_97,
+			"10:10:00;02": timecode.Rate_29_97,
+			"05:06:07:29": timecode.Rate_30,
+		}
+		for str, rate := range cases {
+			messages, err := timecode.MustParse(str, rate).MTCQuarterFrames()
+			require.NoError(t, err)
+
+			var decoder timecode.MTCDecoder
+			for i, msg := range messages {
+				tc, err := decoder.Decode(msg[:])
+				require.NoError(t, err)
+				if i < len(messages)-1 {
+					require.Nil(t, tc)
+				} else {
+					require.NotNil(t, tc)
+					require.Equal(t, str, tc.String())
+				}
+			}
+		}
+	})
+	t.Run("full frame round trip", func(t *testing.T) {
+		msg, err := timecode.MustParse("01:00:00;02", timecode.Rate_29_97).MTCFullFrame()
+		require.NoError(t, err)
+
+		var decoder timecode.MTCDecoder
+		tc, err := decoder.Decode(msg)
+		require.NoError(t, err)
+		require.Equal(t, "01:00:00;02", tc.String())
+	})
+	t.Run("out of sequence quarter frames are discarded", func(t *testing.T) {
+		messages, err := timecode.MustParse("01:02:03:04", timecode.Rate_24).MTCQuarterFrames()
+		require.NoError(t, err)
+
+		var decoder timecode.MTCDecoder
+		for _, msg := range messages[3:] {
+			tc, err := decoder.Decode(msg[:])
+			require.NoError(t, err)
+			require.Nil(t, tc)
+		}
+		var tc *timecode.Timecode
+		for _, msg := range messages {
+			tc, err = decoder.Decode(msg[:])
+			require.NoError(t, err)
+		}
+		require.Equal(t, "01:02:03:04", tc.String())
+	})
+	t.Run("invalid messages", func(t *testing.T) {
+		var decoder timecode.MTCDecoder
+		_, err := decoder.Decode([]byte{0x90, 0x40})
+		require.Error(t, err)
+		_, err = decoder.Decode([]byte{0xF0, 0x7F, 0x7F, 0x01, 0x01, 0x21, 37, 52, 30, 0xF7})
+		require.Error(t, err)
+	})
+}
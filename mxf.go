@@ -0,0 +1,371 @@
+package timecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MXFKey is a SMPTE universal label, used as the key of a KLV triplet in an MXF file
+type MXFKey [16]byte
+
+var (
+	// MXFKey_TimecodeComponent is the key of a timecode component local set in the header metadata
+	MXFKey_TimecodeComponent = MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x53, 0x01, 0x01, 0x0d, 0x01, 0x01, 0x01, 0x01, 0x01, 0x14, 0x00}
+	// MXFKey_SystemMetadataPack is the key of the system metadata pack of a system item
+	MXFKey_SystemMetadataPack = MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x05, 0x01, 0x01, 0x0d, 0x01, 0x03, 0x01, 0x04, 0x01, 0x01, 0x00}
+)
+
+// Matches checks if this key matches another key, ignoring the version of the registry
+func (k MXFKey) Matches(other MXFKey) bool {
+	return bytes.Equal(k[:7], other[:7]) && bytes.Equal(k[8:], other[8:])
+}
+
+// isBodyPartitionPack checks if the key is the key of a body or footer partition pack
+func (k MXFKey) isBodyPartitionPack() bool {
+	prefix := MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x05, 0x01, 0x01, 0x0d, 0x01, 0x02, 0x01, 0x01}
+	return bytes.Equal(k[:7], prefix[:7]) && bytes.Equal(k[8:13], prefix[8:13]) && (k[13] == 0x03 || k[13] == 0x04)
+}
+
+// isEssenceElement checks if the key is the key of an essence element
+func (k MXFKey) isEssenceElement() bool {
+	prefix := MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x01, 0x02, 0x01, 0x01, 0x0d, 0x01, 0x03, 0x01}
+	return bytes.Equal(k[:7], prefix[:7]) && bytes.Equal(k[8:12], prefix[8:12])
+}
+
+// KLV is a key-length-value triplet from an MXF file
+type KLV struct {
+	Key   MXFKey
+	Value []byte
+}
+
+// KLVReader reads the KLV triplets of an MXF file
+type KLVReader struct {
+	r io.Reader
+}
+
+// NewKLVReader creates a reader for the KLV triplets in a stream
+func NewKLVReader(r io.Reader) *KLVReader {
+	return &KLVReader{r}
+}
+
+// Next reads the next KLV triplet. It returns io.EOF when there are no more triplets.
+func (r *KLVReader) Next() (*KLV, error) {
+	var klv KLV
+	if _, err := io.ReadFull(r.r, klv.Key[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, errors.New("truncated KLV key")
+		}
+		return nil, err
+	}
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the value without trusting the length for the allocation, in case the file is truncated
+	if klv.Value, err = io.ReadAll(io.LimitReader(r.r, length)); err != nil {
+		return nil, err
+	}
+	if int64(len(klv.Value)) < length {
+		return nil, errors.New("truncated KLV value")
+	}
+	return &klv, nil
+}
+
+// readLength reads a BER encoded length
+func (r *KLVReader) readLength() (int64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r.r, b[:1]); err != nil {
+		return 0, errors.New("truncated KLV length")
+	}
+	if b[0] < 0x80 {
+		return int64(b[0]), nil
+	}
+	n := int(b[0] & 0x7f)
+	if n == 0 || n > 8 {
+		return 0, errors.New("invalid KLV length")
+	}
+	b[0] = 0
+	if _, err := io.ReadFull(r.r, b[8-n:]); err != nil {
+		return 0, errors.New("truncated KLV length")
+	}
+	length := binary.BigEndian.Uint64(b[:])
+	if length > math.MaxInt64 {
+		return 0, errors.New("KLV length is too large")
+	}
+	return int64(length), nil
+}
+
+// MXFTimecodeComponent is a timecode component from the header metadata of an MXF file
+type MXFTimecodeComponent struct {
+	// StartTimecode is the frame count of the first timecode
+	StartTimecode int64
+	// RoundedTimecodeBase is the nominal frame rate of the timecodes
+	RoundedTimecodeBase uint16
+	DropFrame           bool
+}
+
+// Local tags of the timecode component properties
+const (
+	mxfTag_StartTimecode       = 0x1501
+	mxfTag_RoundedTimecodeBase = 0x1502
+	mxfTag_DropFrame           = 0x1503
+)
+
+// ParseMXFTimecodeComponent parses the value of a timecode component local set
+func ParseMXFTimecodeComponent(value []byte) (*MXFTimecodeComponent, error) {
+	var c MXFTimecodeComponent
+	var found uint
+	for len(value) > 0 {
+		if len(value) < 4 {
+			return nil, errors.New("truncated local set item")
+		}
+		tag := binary.BigEndian.Uint16(value)
+		length := int(binary.BigEndian.Uint16(value[2:]))
+		if len(value) < 4+length {
+			return nil, errors.New("truncated local set item")
+		}
+		item := value[4 : 4+length]
+		value = value[4+length:]
+
+		switch tag {
+		case mxfTag_StartTimecode:
+			if length != 8 {
+				return nil, errors.New("invalid StartTimecode length")
+			}
+			c.StartTimecode = int64(binary.BigEndian.Uint64(item))
+			found |= 1
+		case mxfTag_RoundedTimecodeBase:
+			if length != 2 {
+				return nil, errors.New("invalid RoundedTimecodeBase length")
+			}
+			c.RoundedTimecodeBase = binary.BigEndian.Uint16(item)
+			found |= 2
+		case mxfTag_DropFrame:
+			if length != 1 {
+				return nil, errors.New("invalid DropFrame length")
+			}
+			c.DropFrame = item[0] != 0
+			found |= 4
+		}
+	}
+	if found != 7 {
+		return nil, errors.New("timecode component is missing required properties")
+	}
+	return &c, nil
+}
+
+// Rate gets the most likely frame rate of the timecodes. The timecode component only has the nominal
+// rate, so drop frame timecodes are assumed to be at a pull-down rate (ie. 29.97), and the others at a
+// whole rate (ie. 24, rather than 23.976). Use Timecode with the edit rate of the track for the exact rate.
+func (c *MXFTimecodeComponent) Rate() (Rate, error) {
+	if c.RoundedTimecodeBase == 0 {
+		return Rate{}, errors.New("timecode component has no rate")
+	}
+	if c.DropFrame {
+		return RateFromFraction(int(c.RoundedTimecodeBase)*1000, 1001)
+	}
+	return RateFromFraction(int(c.RoundedTimecodeBase), 1)
+}
+
+// Timecode gets the start timecode at the given rate, which must have the rounded timecode base as its
+// nominal rate
+func (c *MXFTimecodeComponent) Timecode(rate Rate) (*Timecode, error) {
+	if rate.Nominal != int(c.RoundedTimecodeBase) {
+		return nil, fmt.Errorf("rate %s doesn't match timecode base %d", rate.Str, c.RoundedTimecodeBase)
+	}
+	if c.DropFrame && !rate.IsDropFrameCapable() {
+		return nil, fmt.Errorf("rate %s can't be drop frame", rate.Str)
+	}
+	return FromFrame(c.StartTimecode, rate, c.DropFrame), nil
+}
+
+// ReadMXFTimecodeComponents reads the timecode components from the header metadata of an MXF file. It stops
+// reading at the first body partition or essence element.
+func ReadMXFTimecodeComponents(r io.Reader) ([]*MXFTimecodeComponent, error) {
+	var components []*MXFTimecodeComponent
+	klvs := NewKLVReader(r)
+	for {
+		klv, err := klvs.Next()
+		if err == io.EOF {
+			return components, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case klv.Key.isBodyPartitionPack() || klv.Key.isEssenceElement():
+			return components, nil
+		case klv.Key.Matches(MXFKey_TimecodeComponent):
+			component, err := ParseMXFTimecodeComponent(klv.Value)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, component)
+		}
+	}
+}
+
+// DecodeSMPTE12M decodes a timecode from the 4 bytes of SMPTE 12M binary coded decimal used in MXF system
+// items and SDI ancillary data, in the order frames, seconds, minutes, hours. The drop frame flag of the data
+// is used. For rates above 30 frames per second, the frames are counted in pairs along with a flag for the
+// second frame of the pair.
+func DecodeSMPTE12M(data []byte, rate Rate) (*Timecode, error) {
+	if len(data) < 4 {
+		return nil, errors.New("SMPTE 12M timecode is too short")
+	}
+	digits := func(b byte, tensMask byte) (int64, bool) {
+		units, tens := b&0x0f, (b>>4)&tensMask
+		return int64(tens)*10 + int64(units), units <= 9
+	}
+	frames, ok1 := digits(data[0], 0x03)
+	seconds, ok2 := digits(data[1], 0x07)
+	minutes, ok3 := digits(data[2], 0x07)
+	hours, ok4 := digits(data[3], 0x03)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, errors.New("invalid SMPTE 12M digits")
+	}
+	if rate.Nominal > 30 {
+		frames = frames*2 + int64(smpte12MPairFlag(data, rate))
+	}
+	dropFrame := data[0]&0x40 != 0
+	if dropFrame && !rate.IsDropFrameCapable() {
+		return nil, fmt.Errorf("rate %s can't be drop frame", rate.Str)
+	}
+	if frames >= int64(rate.Nominal) || seconds > 59 || minutes > 59 || hours > 23 {
+		return nil, errors.New("SMPTE 12M timecode is out of range")
+	}
+	return FromComponents(Components{hours, minutes, seconds, frames}, rate, dropFrame), nil
+}
+
+// SMPTE12M encodes the timecode as 4 bytes of SMPTE 12M binary coded decimal. See DecodeSMPTE12M.
+func (t *Timecode) SMPTE12M() ([]byte, error) {
+	if t.rate.Nominal > 60 {
+		return nil, fmt.Errorf("rate %s cannot be represented in SMPTE 12M", t.rate.Str)
+	}
+	if t.frame < 0 {
+		return nil, errors.New("negative timecodes cannot be represented in SMPTE 12M")
+	}
+	c := t.Components()
+	if c.Hours > 23 {
+		return nil, errors.New("timecodes beyond 24 hours cannot be represented in SMPTE 12M")
+	}
+	bcd := func(n int64) byte {
+		return byte(n/10)<<4 | byte(n%10)
+	}
+	frames := c.Frames
+	if t.rate.Nominal > 30 {
+		frames /= 2
+	}
+	data := []byte{bcd(frames), bcd(c.Seconds), bcd(c.Minutes), bcd(c.Hours)}
+	if t.dropFrame {
+		data[0] |= 0x40
+	}
+	if t.rate.Nominal > 30 && c.Frames%2 == 1 {
+		if t.rate.Nominal%25 == 0 {
+			data[3] |= 0x80
+		} else {
+			data[1] |= 0x80
+		}
+	}
+	return data, nil
+}
+
+// smpte12MPairFlag gets the flag for the second frame of a pair, which is in the hours for rates based on
+// 25 frames per second, and in the seconds for the others
+func smpte12MPairFlag(data []byte, rate Rate) byte {
+	if rate.Nominal%25 == 0 {
+		return data[3] >> 7
+	}
+	return data[1] >> 7
+}
+
+// mxfTimestampType_SMPTE12M is the type of a SMPTE 331M timestamp that holds a SMPTE 12M timecode
+const mxfTimestampType_SMPTE12M = 0x81
+
+// DecodeMXFTimestamp decodes a 17 byte SMPTE 331M timestamp from an MXF system item, which must hold a
+// SMPTE 12M timecode
+func DecodeMXFTimestamp(data []byte, rate Rate) (*Timecode, error) {
+	if len(data) != 17 {
+		return nil, errors.New("MXF timestamp must be 17 bytes")
+	}
+	if data[0] != mxfTimestampType_SMPTE12M {
+		return nil, fmt.Errorf("MXF timestamp type 0x%02x is not a timecode", data[0])
+	}
+	return DecodeSMPTE12M(data[1:5], rate)
+}
+
+// MXFSystemItem is the system metadata pack of an MXF system item, which carries timecodes for each
+// content package in SDTI-CP compatible files
+type MXFSystemItem struct {
+	Rate            Rate
+	ContinuityCount uint16
+
+	// CreationTimecode and UserTimecode are the timecodes of the creation and user timestamps, which are
+	// nil if the timestamps are missing or don't hold timecodes
+	CreationTimecode *Timecode
+	UserTimecode     *Timecode
+}
+
+// mxfPackageRates are the rates of content packages, indexed by their rate code
+var mxfPackageRates = []int{0, 24, 25, 30, 48, 50, 60, 72, 75, 90, 96, 100, 120}
+
+// ParseMXFSystemItem parses the value of a system metadata pack
+func ParseMXFSystemItem(value []byte) (*MXFSystemItem, error) {
+	if len(value) < 7 {
+		return nil, errors.New("system metadata pack is too short")
+	}
+	bitmap := value[0]
+
+	// The rate is a code for the nominal rate, with a flag for pull-down rates
+	code := int(value[1]>>1) & 0x1f
+	if code == 0 || code >= len(mxfPackageRates) {
+		return nil, fmt.Errorf("invalid content package rate code %d", code)
+	}
+	num, den := mxfPackageRates[code], 1
+	if value[1]&0x01 != 0 {
+		num, den = num*1000, 1001
+	}
+	rate, err := RateFromFraction(num, den)
+	if err != nil {
+		return nil, err
+	}
+	item := &MXFSystemItem{
+		Rate:            rate,
+		ContinuityCount: binary.BigEndian.Uint16(value[5:]),
+	}
+
+	// Skip the label if there is one, and then read the timestamps that are present
+	rest := value[7:]
+	if bitmap&0x40 != 0 {
+		if len(rest) < 16 {
+			return nil, errors.New("system metadata pack is too short")
+		}
+		rest = rest[16:]
+	}
+	for _, timestamp := range []struct {
+		bit byte
+		tc  **Timecode
+	}{
+		{0x20, &item.CreationTimecode},
+		{0x10, &item.UserTimecode},
+	} {
+		if bitmap&timestamp.bit == 0 {
+			continue
+		}
+		if len(rest) < 17 {
+			return nil, errors.New("system metadata pack is too short")
+		}
+		if rest[0] == mxfTimestampType_SMPTE12M {
+			if *timestamp.tc, err = DecodeMXFTimestamp(rest[:17], rate); err != nil {
+				return nil, err
+			}
+		}
+		rest = rest[17:]
+	}
+	return item, nil
+}
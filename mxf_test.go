@@ -0,0 +1,186 @@
+package timecode_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+// klv encodes a KLV triplet, using a long form length if long is true
+func klv(key timecode.MXFKey, value []byte, long bool) []byte {
+	out := append([]byte{}, key[:]...)
+	if long {
+		out = append(out, 0x83, byte(len(value)>>16), byte(len(value)>>8), byte(len(value)))
+	} else {
+		out = append(out, byte(len(value)))
+	}
+	return append(out, value...)
+}
+
+// timecodeComponent encodes a timecode component local set value
+func timecodeComponent(start int64, base uint16, dropFrame bool) []byte {
+	df := byte(0)
+	if dropFrame {
+		df = 1
+	}
+	return []byte{
+		0x3c, 0x0a, 0x00, 0x02, 0xab, 0xcd, // instance UID, shortened
+		0x15, 0x01, 0x00, 0x08, byte(start >> 56), byte(start >> 48), byte(start >> 40), byte(start >> 32), byte(start >> 24), byte(start >> 16), byte(start >> 8), byte(start),
+		0x15, 0x02, 0x00, 0x02, byte(base >> 8), byte(base),
+		0x15, 0x03, 0x00, 0x01, df,
+	}
+}
+
+func TestParseMXFTimecodeComponent(t *testing.T) {
+	c, err := timecode.ParseMXFTimecodeComponent(timecodeComponent(107892, 30, true))
+	require.NoError(t, err)
+	require.Equal(t, &timecode.MXFTimecodeComponent{StartTimecode: 107892, RoundedTimecodeBase: 30, DropFrame: true}, c)
+	rate, err := c.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, rate)
+	tc, err := c.Timecode(rate)
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00;00", tc.String())
+
+	// The exact rate comes from the edit rate of the track
+	c, err = timecode.ParseMXFTimecodeComponent(timecodeComponent(86400, 24, false))
+	require.NoError(t, err)
+	rate, err = c.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_24, rate)
+	tc, err = c.Timecode(timecode.Rate_23_976)
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00:00", tc.String())
+	_, err = c.Timecode(timecode.Rate_25)
+	require.Error(t, err)
+
+	for _, value := range [][]byte{
+		timecodeComponent(0, 25, false)[:24],
+		timecodeComponent(0, 25, false)[:27],
+		{0x15, 0x01, 0x00, 0x04, 0, 0, 0, 0},
+	} {
+		_, err := timecode.ParseMXFTimecodeComponent(value)
+		require.Error(t, err)
+	}
+}
+
+func TestReadMXFTimecodeComponents(t *testing.T) {
+	headerPartition := timecode.MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x05, 0x01, 0x01, 0x0d, 0x01, 0x02, 0x01, 0x01, 0x02, 0x04, 0x00}
+	bodyPartition := timecode.MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x05, 0x01, 0x01, 0x0d, 0x01, 0x02, 0x01, 0x01, 0x03, 0x04, 0x00}
+	otherSet := timecode.MXFKey{0x06, 0x0e, 0x2b, 0x34, 0x02, 0x53, 0x01, 0x01, 0x0d, 0x01, 0x01, 0x01, 0x01, 0x01, 0x11, 0x00}
+	olderTimecodeComponent := timecode.MXFKey_TimecodeComponent
+	olderTimecodeComponent[7] = 0x02
+
+	var file bytes.Buffer
+	file.Write(klv(headerPartition, make([]byte, 88), true))
+	file.Write(klv(otherSet, make([]byte, 20), false))
+	file.Write(klv(timecode.MXFKey_TimecodeComponent, timecodeComponent(90000, 25, false), false))
+	file.Write(klv(olderTimecodeComponent, timecodeComponent(107892, 30, true), true))
+	file.Write(klv(bodyPartition, make([]byte, 88), true))
+	file.Write(klv(timecode.MXFKey_TimecodeComponent, timecodeComponent(0, 25, false), false))
+
+	components, err := timecode.ReadMXFTimecodeComponents(&file)
+	require.NoError(t, err)
+	require.Equal(t, []*timecode.MXFTimecodeComponent{
+		{StartTimecode: 90000, RoundedTimecodeBase: 25},
+		{StartTimecode: 107892, RoundedTimecodeBase: 30, DropFrame: true},
+	}, components)
+}
+
+func TestKLVReader(t *testing.T) {
+	data := klv(timecode.MXFKey_SystemMetadataPack, []byte{1, 2, 3}, true)
+	r := timecode.NewKLVReader(bytes.NewReader(data))
+	triplet, err := r.Next()
+	require.NoError(t, err)
+	require.Equal(t, timecode.MXFKey_SystemMetadataPack, triplet.Key)
+	require.Equal(t, []byte{1, 2, 3}, triplet.Value)
+	_, err = r.Next()
+	require.Equal(t, io.EOF, err)
+
+	// Truncated triplets
+	for _, n := range []int{8, 16, 18, len(data) - 1} {
+		_, err := timecode.NewKLVReader(bytes.NewReader(data[:n])).Next()
+		require.Error(t, err)
+		require.NotEqual(t, io.EOF, err)
+	}
+}
+
+func TestDecodeSMPTE12M(t *testing.T) {
+	cases := []struct {
+		data []byte
+		rate timecode.Rate
+		tc   string
+	}{
+		{[]byte{0x12, 0x34, 0x56, 0x12}, timecode.Rate_25, "12:56:34:12"},
+		{[]byte{0x42, 0x00, 0x01, 0x01}, timecode.Rate_29_97, "01:01:00;02"},
+		{[]byte{0x29, 0x59, 0x59, 0x23}, timecode.Rate_30, "23:59:59:29"},
+		{[]byte{0x29, 0xd9, 0x59, 0x23}, timecode.Rate_60, "23:59:59:59"},
+		{[]byte{0x24, 0x00, 0x00, 0x81}, timecode.Rate_50, "01:00:00:49"},
+		{[]byte{0x44, 0x80, 0x01, 0x00}, timecode.Rate_59_94, "00:01:00;09"},
+	}
+	for _, c := range cases {
+		tc, err := timecode.DecodeSMPTE12M(c.data, c.rate)
+		require.NoError(t, err)
+		require.Equal(t, c.tc, tc.String())
+
+		data, err := tc.SMPTE12M()
+		require.NoError(t, err)
+		require.Equal(t, c.data, data)
+	}
+
+	for _, data := range [][]byte{
+		{0x00, 0x00, 0x00},
+		{0x0a, 0x00, 0x00, 0x00},
+		{0x25, 0x00, 0x00, 0x00},
+		{0x40, 0x00, 0x00, 0x00},
+		{0x00, 0x60, 0x00, 0x00},
+		{0x00, 0x00, 0x00, 0x24},
+	} {
+		_, err := timecode.DecodeSMPTE12M(data, timecode.Rate_25)
+		require.Error(t, err)
+	}
+	_, err := timecode.FromFrame(-1, timecode.Rate_25, false).SMPTE12M()
+	require.Error(t, err)
+	_, err = timecode.MustParse("24:00:00:00", timecode.Rate_25).SMPTE12M()
+	require.Error(t, err)
+}
+
+func TestParseMXFSystemItem(t *testing.T) {
+	timestamp := func(tc []byte) []byte {
+		return append(append([]byte{0x81}, tc...), make([]byte, 12)...)
+	}
+	value := []byte{0x70, 0x07, 0x00, 0x00, 0x00, 0x01, 0x2c}
+	value = append(value, make([]byte, 16)...)
+	value = append(value, timestamp([]byte{0x00, 0x00, 0x00, 0x10})...)
+	value = append(value, timestamp([]byte{0x42, 0x00, 0x01, 0x10})...)
+
+	item, err := timecode.ParseMXFSystemItem(value)
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, item.Rate)
+	require.Equal(t, uint16(300), item.ContinuityCount)
+	require.Equal(t, "10:00:00:00", item.CreationTimecode.String())
+	require.Equal(t, "10:01:00;02", item.UserTimecode.String())
+
+	// Only the user timestamp, at 25 fps
+	value = append([]byte{0x10, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00}, timestamp([]byte{0x01, 0x00, 0x00, 0x10})...)
+	item, err = timecode.ParseMXFSystemItem(value)
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_25, item.Rate)
+	require.Nil(t, item.CreationTimecode)
+	require.Equal(t, "10:00:00:01", item.UserTimecode.String())
+
+	for _, value := range [][]byte{
+		{0x10, 0x04, 0x00},
+		{0x10, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x00, 0x3e, 0x00, 0x00, 0x00, 0x00, 0x00},
+	} {
+		_, err := timecode.ParseMXFSystemItem(value)
+		require.Error(t, err)
+	}
+	_, err = timecode.DecodeMXFTimestamp(append([]byte{0x82}, make([]byte, 16)...), timecode.Rate_25)
+	require.Error(t, err)
+}
@@ -6,8 +6,8 @@ import (
 	"strconv"
 )
 
-// TimecodeRegex is the pattern for a valid SMPTE timecode
-var TimecodeRegex = regexp.MustCompile(`^(\d\d)(:|;)(\d\d)(:|;)(\d\d)(:|;)(\d+)$`)
+// TimecodeRegex is the pattern for a valid SMPTE timecode, with optional sub-frames
+var TimecodeRegex = regexp.MustCompile(`^(\d\d)(:|;)(\d\d)(:|;)(\d\d)(:|;)(\d+)(?:\.(\d+))?$`)
 
 // MustParse parses a timecode from a string, and treats it using the provided frame rate value
 func MustParse(timecode string, rate Rate) *Timecode {
@@ -18,11 +18,23 @@ func MustParse(timecode string, rate Rate) *Timecode {
 	return tc
 }
 
-// Parse parses a timecode from a string, and treats it using the provided frame rate value
+// Parse parses a timecode from a string, and treats it using the provided frame rate value. Sub-frames
+// (ie. "01:00:00:00.42") are treated using the default sub-frame resolution, and timecodes with a field
+// mark (ie. "01:00:00.12") are in the second field.
 func Parse(timecode string, rate Rate) (*Timecode, error) {
+	return ParseSubframes(timecode, rate, DefaultSubframeResolution)
+}
+
+// ParseSubframes parses a timecode from a string, and treats it using the provided frame rate value and
+// sub-frame resolution
+func ParseSubframes(timecode string, rate Rate, subframeResolution int) (*Timecode, error) {
 	// Match it against the regular expression
 	match := TimecodeRegex.FindStringSubmatch(timecode)
 	if match == nil {
+		// Timecodes with a field mark (ie. "01:00:00.12") are in the second field
+		if hasFieldMark(timecode) {
+			return ParseField(timecode, rate)
+		}
 		return nil, errors.New("invalid timecode format")
 	}
 
@@ -36,12 +48,22 @@ func Parse(timecode string, rate Rate) (*Timecode, error) {
 	dropFrame := match[6] == ";"
 
 	// Combine the components
-	return FromComponents(Components{
+	tc := FromComponents(Components{
 		hours,
 		minutes,
 		seconds,
 		frames,
-	}, rate, dropFrame), nil
+	}, rate, dropFrame)
+
+	// Add the sub-frames if there are any
+	if match[8] == "" {
+		return tc, nil
+	}
+	subframe, _ := strconv.ParseInt(match[8], 10, 64)
+	if subframeResolution <= 0 || subframe >= int64(subframeResolution) {
+		return nil, errors.New("invalid timecode sub-frames")
+	}
+	return tc.WithSubframe(subframe, subframeResolution), nil
 }
 
 func FromComponents(components Components, rate Rate, dropFrame bool) *Timecode {
@@ -75,8 +97,19 @@ func FromComponents(components Components, rate Rate, dropFrame bool) *Timecode
 
 func FromFrame(frame int64, rate Rate, dropFrame bool) *Timecode {
 	return &Timecode{
-		frame,
-		rate,
-		dropFrame,
+		frame:     frame,
+		rate:      rate,
+		dropFrame: dropFrame,
 	}
 }
+
+// FromSeconds creates a timecode for the frame that contains the given time in seconds
+func FromSeconds(seconds Rational, rate Rate, dropFrame bool) *Timecode {
+	return FromFrame(rate.Frames(seconds).Floor(), rate, dropFrame)
+}
+
+// hasFieldMark checks if a timecode marks the second field using its final separator (ie. "01:00:00.12")
+func hasFieldMark(timecode string) bool {
+	field := FieldTimecodeRegex.FindStringSubmatch(timecode)
+	return field != nil && field[8] == "" && field[6] != ":" && field[6] != ";"
+}
@@ -0,0 +1,91 @@
+package timecode
+
+import (
+	"errors"
+)
+
+// PulldownCadence is a pattern for spreading 4 film frames over the 10 fields of 5 video frames
+type PulldownCadence int
+
+const (
+	// Pulldown_2_3 is the standard 2:3 pulldown, which gives video frames AA, BB, BC, CD and DD
+	Pulldown_2_3 PulldownCadence = iota
+	// Pulldown_2_3_3_2 is the advanced 2:3:3:2 pulldown, which gives video frames AA, BB, BC, CC and DD.
+	// Only the BC frame is mixed, so it's easier to remove.
+	Pulldown_2_3_3_2
+)
+
+// fields gets the film frame (0-3 for A-D) of each of the 10 fields in a cycle of the cadence
+func (c PulldownCadence) fields() [10]int {
+	if c == Pulldown_2_3_3_2 {
+		return [10]int{0, 0, 1, 1, 1, 2, 2, 2, 3, 3}
+	}
+	return [10]int{0, 0, 1, 1, 1, 2, 2, 3, 3, 3}
+}
+
+// Pulldown maps between film timecodes (ie. 23.976) and video timecodes (ie. 29.97) of telecined material.
+// The cadence is anchored by a reference video frame that is an A-frame, and the film frame it shows.
+type Pulldown struct {
+	Cadence     PulldownCadence
+	VideoAFrame *Timecode
+	FilmAFrame  *Timecode
+}
+
+// NewPulldown creates a pulldown mapping, given the timecode of a video A-frame and the timecode of the
+// film frame it shows. The video rate must be 5/4 of the film rate, as with 23.976 and 29.97.
+func NewPulldown(cadence PulldownCadence, videoAFrame, filmAFrame *Timecode) (*Pulldown, error) {
+	if filmAFrame.rate.Fraction().Mul(NewRational(5, 4)).Cmp(videoAFrame.rate.Fraction()) != 0 {
+		return nil, errors.New("pulldown requires a video rate that is 5/4 of the film rate")
+	}
+	return &Pulldown{cadence, videoAFrame, filmAFrame}, nil
+}
+
+// VideoToFilm gets the film timecode shown by the first field of a video frame, along with the cadence
+// letters of the video frame. The letters are "A", "B", "C" or "D" for video frames showing a single film
+// frame, and two letters (ie. "BC") for mixed video frames showing a different film frame in each field.
+// The film timecode is drop frame if the film A-frame is.
+func (p *Pulldown) VideoToFilm(video *Timecode) (*Timecode, string, error) {
+	if !video.rate.Equal(p.VideoAFrame.rate) {
+		return nil, "", errors.New("video timecode doesn't match the rate of the pulldown")
+	}
+
+	// Find the position of the video frame in the cadence
+	offset := video.frame - p.VideoAFrame.frame
+	cycle := NewRational(offset, 5).Floor()
+	position := offset - cycle*5
+	fields := p.Cadence.fields()
+	first, second := fields[position*2], fields[position*2+1]
+
+	letters := string(rune('A' + first))
+	if second != first {
+		letters += string(rune('A' + second))
+	}
+	film := FromFrame(p.FilmAFrame.frame+cycle*4+int64(first), p.FilmAFrame.rate, p.FilmAFrame.dropFrame)
+	return film, letters, nil
+}
+
+// FilmToVideo gets the video timecode of a film frame. This is the first video frame that shows only that
+// film frame if there is one, or otherwise the video frame that shows its first field. The video timecode
+// is drop frame if the video A-frame is.
+func (p *Pulldown) FilmToVideo(film *Timecode) (*Timecode, error) {
+	if !film.rate.Equal(p.FilmAFrame.rate) {
+		return nil, errors.New("film timecode doesn't match the rate of the pulldown")
+	}
+
+	// Find the position of the film frame in the cadence
+	offset := film.frame - p.FilmAFrame.frame
+	cycle := NewRational(offset, 4).Floor()
+	letter := int(offset - cycle*4)
+	fields := p.Cadence.fields()
+	start := 0
+	for fields[start] != letter {
+		start++
+	}
+
+	// Prefer the next whole frame, if the film frame starts in the second field
+	position := start / 2
+	if next := (start + 1) / 2; next < 5 && fields[next*2] == letter && fields[next*2+1] == letter {
+		position = next
+	}
+	return FromFrame(p.VideoAFrame.frame+cycle*5+int64(position), p.VideoAFrame.rate, p.VideoAFrame.dropFrame), nil
+}
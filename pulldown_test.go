@@ -0,0 +1,131 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPulldown_VideoToFilm(t *testing.T) {
+	type testCase struct {
+		video, film, letters string
+	}
+	t.Run("2:3", func(t *testing.T) {
+		p, err := timecode.NewPulldown(
+			timecode.Pulldown_2_3,
+			timecode.MustParse("01:00:00:00", timecode.Rate_29_97),
+			timecode.MustParse("01:00:00:00", timecode.Rate_23_976),
+		)
+		require.NoError(t, err)
+		cases := []testCase{
+			{"01:00:00:00", "01:00:00:00", "A"},
+			{"01:00:00:01", "01:00:00:01", "B"},
+			{"01:00:00:02", "01:00:00:01", "BC"},
+			{"01:00:00:03", "01:00:00:02", "CD"},
+			{"01:00:00:04", "01:00:00:03", "D"},
+			{"01:00:00:05", "01:00:00:04", "A"},
+			{"01:00:01:00", "01:00:01:00", "A"},
+			{"00:59:59:29", "00:59:59:23", "D"},
+			{"00:59:59:27", "00:59:59:21", "BC"},
+		}
+		for _, tc := range cases {
+			film, letters, err := p.VideoToFilm(timecode.MustParse(tc.video, timecode.Rate_29_97))
+			require.NoError(t, err)
+			require.Equal(t, tc.film, film.String(), tc.video)
+			require.Equal(t, tc.letters, letters, tc.video)
+		}
+	})
+	t.Run("2:3:3:2", func(t *testing.T) {
+		p, err := timecode.NewPulldown(
+			timecode.Pulldown_2_3_3_2,
+			timecode.MustParse("01:00:00:00", timecode.Rate_29_97),
+			timecode.MustParse("01:00:00:00", timecode.Rate_23_976),
+		)
+		require.NoError(t, err)
+		cases := []testCase{
+			{"01:00:00:00", "01:00:00:00", "A"},
+			{"01:00:00:01", "01:00:00:01", "B"},
+			{"01:00:00:02", "01:00:00:01", "BC"},
+			{"01:00:00:03", "01:00:00:02", "C"},
+			{"01:00:00:04", "01:00:00:03", "D"},
+		}
+		for _, tc := range cases {
+			film, letters, err := p.VideoToFilm(timecode.MustParse(tc.video, timecode.Rate_29_97))
+			require.NoError(t, err)
+			require.Equal(t, tc.film, film.String(), tc.video)
+			require.Equal(t, tc.letters, letters, tc.video)
+		}
+	})
+	t.Run("drop frame video", func(t *testing.T) {
+		p, err := timecode.NewPulldown(
+			timecode.Pulldown_2_3,
+			timecode.MustParse("00:01:00;02", timecode.Rate_29_97),
+			timecode.MustParse("00:01:00:00", timecode.Rate_23_976),
+		)
+		require.NoError(t, err)
+		film, letters, err := p.VideoToFilm(timecode.MustParse("00:00:59;29", timecode.Rate_29_97))
+		require.NoError(t, err)
+		require.Equal(t, "00:00:59:23", film.String())
+		require.Equal(t, "D", letters)
+
+		video, err := p.FilmToVideo(timecode.MustParse("00:01:00:01", timecode.Rate_23_976))
+		require.NoError(t, err)
+		require.Equal(t, "00:01:00;03", video.String())
+	})
+}
+
+func TestPulldown_FilmToVideo(t *testing.T) {
+	type testCase struct {
+		cadence     timecode.PulldownCadence
+		film, video string
+	}
+	cases := []testCase{
+		{timecode.Pulldown_2_3, "01:00:00:00", "01:00:00:00"},
+		{timecode.Pulldown_2_3, "01:00:00:01", "01:00:00:01"},
+		{timecode.Pulldown_2_3, "01:00:00:02", "01:00:00:02"},
+		{timecode.Pulldown_2_3, "01:00:00:03", "01:00:00:04"},
+		{timecode.Pulldown_2_3, "01:00:00:04", "01:00:00:05"},
+		{timecode.Pulldown_2_3, "00:59:59:23", "00:59:59:29"},
+		{timecode.Pulldown_2_3_3_2, "01:00:00:02", "01:00:00:03"},
+		{timecode.Pulldown_2_3_3_2, "01:00:00:03", "01:00:00:04"},
+	}
+	for _, tc := range cases {
+		p, err := timecode.NewPulldown(
+			tc.cadence,
+			timecode.MustParse("01:00:00:00", timecode.Rate_29_97),
+			timecode.MustParse("01:00:00:00", timecode.Rate_23_976),
+		)
+		require.NoError(t, err)
+		video, err := p.FilmToVideo(timecode.MustParse(tc.film, timecode.Rate_23_976))
+		require.NoError(t, err)
+		require.Equal(t, tc.video, video.String(), tc.film)
+
+		// Whole video frames map back to the same film frame
+		film, letters, err := p.VideoToFilm(video)
+		require.NoError(t, err)
+		if len(letters) == 1 {
+			require.Equal(t, tc.film, film.String())
+		}
+	}
+}
+
+func TestNewPulldown_InvalidRates(t *testing.T) {
+	_, err := timecode.NewPulldown(
+		timecode.Pulldown_2_3,
+		timecode.MustParse("01:00:00:00", timecode.Rate_25),
+		timecode.MustParse("01:00:00:00", timecode.Rate_23_976),
+	)
+	require.Error(t, err)
+
+	p, err := timecode.NewPulldown(
+		timecode.Pulldown_2_3,
+		timecode.MustParse("01:00:00:00", timecode.Rate_30),
+		timecode.MustParse("01:00:00:00", timecode.Rate_24),
+	)
+	require.NoError(t, err)
+	_, _, err = p.VideoToFilm(timecode.MustParse("01:00:00:00", timecode.Rate_29_97))
+	require.Error(t, err)
+	_, err = p.FilmToVideo(timecode.MustParse("01:00:00:00", timecode.Rate_23_976))
+	require.Error(t, err)
+}
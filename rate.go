@@ -1,21 +1,21 @@
 package timecode
 
 import (
+	"fmt"
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
-)
-
-const (
-	dropOccurrencesPerHour = 54
-	secondsPerHour         = 60 * 60
+	"unicode"
 )
 
 var (
 	Rate_23_976 = Rate{"23.976", 24, 0, 24000, 1001}
 	Rate_24     = Rate{"24", 24, 0, 24, 1}
+	Rate_25     = Rate{"25", 25, 0, 25, 1}
 	Rate_30     = Rate{"30", 30, 0, 30, 1}
 	Rate_29_97  = Rate{"29.97", 30, 2, 30000, 1001}
+	Rate_50     = Rate{"50", 50, 0, 50, 1}
 	Rate_60     = Rate{"60", 60, 0, 60, 1}
 	Rate_59_94  = Rate{"59.94", 60, 4, 60000, 1001}
 )
@@ -33,71 +33,232 @@ func (r *Rate) String() string {
 	return r.Str
 }
 
-// ParseRate returns a Rate from a string representation.
-func ParseRate(str string) (Rate, bool) {
-	switch str {
-	case "23.976", "23.98":
-		return Rate_23_976, true
-	case "24":
-		return Rate_24, true
-	case "30":
-		return Rate_30, true
-	case "29.97":
-		return Rate_29_97, true
-	case "60":
-		return Rate_60, true
-	case "59.94":
-		return Rate_59_94, true
-	}
-	return Rate{}, false
-}
-
-// RateFromFraction returns a Rate from a numerator and denominator.
-func RateFromFraction(num, den int) Rate {
-	type fraction struct {
-		num, den int
-	}
-	switch (fraction{num, den}) {
-	case fraction{24000, 1001}:
-		return Rate_23_976
-	case fraction{24, 1}:
-		return Rate_24
-	case fraction{30, 1}:
-		return Rate_30
-	case fraction{30000, 1001}:
-		return Rate_29_97
-	case fraction{60, 1}:
-		return Rate_60
-	case fraction{60000, 1001}:
-		return Rate_59_94
+// Normalize gets the rate with its fraction reduced to lowest terms
+func (r Rate) Normalize() Rate {
+	fraction := reducedFraction(r.Num, r.Den)
+	r.Num, r.Den = fraction[0], fraction[1]
+	return r
+}
+
+// Canonical gets the canonical representation of the rate, which is the same for all equal rates, so
+// that it can be used reliably as a map key. Registered rates are their own canonical representation, and
+// invalid rates with a zero denominator (such as the zero Rate) are returned as they are.
+func (r Rate) Canonical() Rate {
+	if r.Den == 0 {
+		return r
 	}
+	if registered, ok := registry.lookupFraction(r.Num, r.Den); ok && registered.Equal(r) {
+		return registered
+	}
+	r = r.Normalize()
+	r.Str = formatRate(r.Num, r.Den)
+	return r
+}
 
-	// Calculate the nominal frame rate (number of frames in a second without drops)
-	var nominal int
-	if num%den == 0 {
-		nominal = num / den
-	} else {
-		nominal = den - (num % den)
+// Equal checks if this rate is equal to another rate. Rates are equal if they have equivalent fractions
+// and the same nominal rate and drop frames, regardless of their names.
+func (r Rate) Equal(other Rate) bool {
+	return r.Nominal == other.Nominal &&
+		r.Drop == other.Drop &&
+		r.Num*other.Den == other.Num*r.Den
+}
+
+// Compare compares this rate to another rate, ordering them from slowest to fastest, and then by
+// nominal rate and drop frames. It returns -1, 0 or +1. Rates compare as 0 if they are equal.
+func (r Rate) Compare(other Rate) int {
+	switch {
+	case r.Num*other.Den < other.Num*r.Den:
+		return -1
+	case r.Num*other.Den > other.Num*r.Den:
+		return 1
+	case r.Nominal != other.Nominal:
+		return compareInt64s(int64(r.Nominal), int64(other.Nominal))
+	default:
+		return compareInt64s(int64(r.Drop), int64(other.Drop))
 	}
+}
+
+// IsDropFrameCapable checks if drop frame timecodes can be used with this rate
+func (r Rate) IsDropFrameCapable() bool {
+	return r.Drop > 0
+}
+
+// IsInteger checks if this rate is a whole number of frames per second
+func (r Rate) IsInteger() bool {
+	return r.Den > 0 && r.Num%r.Den == 0
+}
+
+// IsNTSC checks if this rate is one of the NTSC pull-down rates, which run at 1000/1001 of a whole
+// number of frames per second (ie. 23.976, 29.97 and 59.94)
+func (r Rate) IsNTSC() bool {
+	fraction := reducedFraction(r.Num, r.Den)
+	return fraction[1] == 1001
+}
+
+// Fraction gets the exact number of frames per second
+func (r Rate) Fraction() Rational {
+	return NewRational(int64(r.Num), int64(r.Den))
+}
+
+// FrameDuration gets the exact duration of a single frame in seconds
+func (r Rate) FrameDuration() Rational {
+	return NewRational(int64(r.Den), int64(r.Num))
+}
 
-	// Format it as a string (ie. 23.976)
-	str := strconv.FormatFloat(float64(num)/float64(den), 'f', 3, 64)
+// Seconds gets the exact time in seconds at which the given frame begins
+func (r Rate) Seconds(frame int64) Rational {
+	return RationalFromInt(frame).Mul(r.FrameDuration())
+}
+
+// Frames gets the exact number of frames in the given number of seconds, including any fraction of a frame
+func (r Rate) Frames(seconds Rational) Rational {
+	return seconds.Mul(r.Fraction())
+}
+
+// formatRate formats a rate fraction as a decimal string with up to 3 decimal places (ie. 23.976)
+func formatRate(num, den int) string {
+	str := NewRational(int64(num), int64(den)).FloatString(3)
 	for strings.HasSuffix(str, "0") {
 		str = str[:len(str)-1]
 	}
-	str = strings.TrimSuffix(str, ".")
+	return strings.TrimSuffix(str, ".")
+}
 
-	// Calculate the number of frames to skip per drop occurrence
-	actualFramesPerHour := num * secondsPerHour / den
-	nominalFramesPerHour := nominal * secondsPerHour
-	totalFramesDropped := nominalFramesPerHour - actualFramesPerHour
-	framesPerDrop := int(math.Round(float64(totalFramesDropped) / float64(dropOccurrencesPerHour)))
+// ParseRate returns a Rate from a string representation. It accepts any of the forms accepted by
+// ParseRateHints, and discards the hints.
+func ParseRate(str string) (Rate, bool) {
+	rate, _, err := ParseRateHints(str)
+	return rate, err == nil
+}
+
+// RateHints are the hints about how a rate is used, found alongside the rate by ParseRateHints
+type RateHints struct {
+	// DropFrame is set if the rate was marked as drop frame (ie. "29.97 DF")
+	DropFrame bool
+	// NonDropFrame is set if the rate was marked as non-drop frame (ie. "30 NDF")
+	NonDropFrame bool
+	// Interlaced is set if the rate was marked as interlaced (ie. "59.94i" or "1080i60")
+	Interlaced bool
+	// Progressive is set if the rate was marked as progressive (ie. "50p" or "25PsF")
+	Progressive bool
+}
+
+// rateRegex is the pattern for a rate with optional resolution, scan and drop frame markers. It's
+// matched against the lowercased string, after removing whitespace, dashes and underscores.
+var rateRegex = regexp.MustCompile(`^(?:\d{3,4}([ip]))?(\d+(?:\.\d+)?)(?:/(\d+))?(?:fps|hz)?(psf|i|p)?(?:fps|hz)?(ndf|df)?$`)
+
+// ParseRateHints parses a rate from the forms commonly found in files and typed by operators, such as
+// names and aliases of registered rates ("23.98"), decimals ("29.970029"), fractions ("24000/1001"),
+// and rates with units, scan or drop frame markers ("23.976fps", "59.94i", "29.97 DF", "1080p25").
+//
+// Interlaced rates above 30 are taken to be field rates, as in "59.94i" or "1080i60", and are converted
+// to the frame rate. Decimals are matched to the closest registered rate when they're within rounding
+// error of it.
+func ParseRateHints(str string) (Rate, RateHints, error) {
+	var hints RateHints
+
+	// Check for an exact name first, so that registered names are never reinterpreted
+	if rate, ok := registry.lookupName(strings.TrimSpace(str)); ok {
+		return rate, hints, nil
+	}
+
+	// Match it against the regular expression
+	normalized := strings.Join(strings.FieldsFunc(strings.ToLower(str), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_'
+	}), "")
+	match := rateRegex.FindStringSubmatch(normalized)
+	if match == nil {
+		return Rate{}, hints, fmt.Errorf("invalid rate %q", str)
+	}
+	if match[1] == "" && match[4] != "" && isResolution(match[2]) {
+		return Rate{}, hints, fmt.Errorf("%q is a video format without a rate", str)
+	}
+	scan := match[1] + match[4]
+	hints.Interlaced = scan == "i"
+	hints.Progressive = scan == "p" || scan == "psf"
+	hints.DropFrame = match[5] == "df"
+	hints.NonDropFrame = match[5] == "ndf"
+
+	// Fractions are matched exactly
+	if match[3] != "" {
+		num, err1 := strconv.Atoi(match[2])
+		den, err2 := strconv.Atoi(match[3])
+		if err1 != nil || err2 != nil || num <= 0 || den <= 0 {
+			return Rate{}, hints, fmt.Errorf("invalid rate %q", str)
+		}
+
+		// Interlaced rates above 30 are field rates
+		if hints.Interlaced && num > 30*den {
+			den *= 2
+		}
+		rate, err := RateFromFraction(num, den)
+		return rate, hints, err
+	}
+
+	// Decimals are matched to the closest registered rate within rounding error
+	fps, err := strconv.ParseFloat(match[2], 64)
+	if err != nil || fps <= 0 {
+		return Rate{}, hints, fmt.Errorf("invalid rate %q", str)
+	}
+	if hints.Interlaced && fps > 30 {
+		fps /= 2
+	}
+	const tolerance = 0.0005
+	best, bestErr := Rate{}, math.Inf(1)
+	for _, rate := range Rates() {
+		expected := float64(rate.Num) / float64(rate.Den)
+		if relErr := math.Abs(fps-expected) / expected; relErr < bestErr {
+			best, bestErr = rate, relErr
+		}
+	}
+	if bestErr <= tolerance {
+		return best, hints, nil
+	}
+	rate, err := measuredRate(fps, tolerance)
+	return rate, hints, err
+}
+
+// isResolution checks if a number is one of the common video line counts, which are
+// written with a scan marker in the same way as rates (ie. "1080i")
+func isResolution(str string) bool {
+	switch str {
+	case "480", "486", "576", "720", "1080", "2160", "4320":
+		return true
+	}
+	return false
+}
+
+// RateFromFraction returns a Rate from a numerator and denominator. Known rates are returned for
+// any equivalent fraction, including custom rates added with RegisterRate.
+//
+// Other rates get a nominal rate of num/den rounded up, and only get drop frames when a standard
+// defines them, which is for multiples of 30000/1001 (ie. 120000/1001 drops 8 frames). An error is
+// returned if the numerator or denominator is not positive.
+func RateFromFraction(num, den int) (Rate, error) {
+	if num <= 0 || den <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate %d/%d", num, den)
+	}
+	if rate, ok := registry.lookupFraction(num, den); ok {
+		return rate, nil
+	}
+
+	// Calculate the nominal frame rate (number of frames in a second without drops)
+	nominal := (num + den - 1) / den
+
+	// Drop frame timecode is defined for 29.97 and its multiples, where it drops 2 frames
+	// for each multiple of 30 in the nominal rate
+	var drop int
+	if fraction := reducedFraction(num, den); fraction[1] == 1001 && fraction[0]%30000 == 0 {
+		if multiple := fraction[0] / 30000; multiple&(multiple-1) == 0 {
+			drop = 2 * multiple
+		}
+	}
 
 	return Rate{
-		Str:     str,
+		Str:     formatRate(num, den),
 		Nominal: nominal,
-		Drop:    framesPerDrop,
+		Drop:    drop,
 		Num:     num,
 		Den:     den,
-	}
+	}, nil
 }
@@ -21,11 +21,42 @@ func TestRateFromFraction(t *testing.T) {
 		{60000, 1001, timecode.Rate_59_94},
 	}
 	for _, tc := range cases {
-		rate := timecode.RateFromFraction(tc.num, tc.den)
+		rate, err := timecode.RateFromFraction(tc.num, tc.den)
+		require.NoError(t, err)
 		require.Equal(t, tc.rate, rate)
 	}
 }
 
+func TestRateFromFractionArbitraryRates(t *testing.T) {
+	type testCase struct {
+		num, den int
+		rate     timecode.Rate
+	}
+	cases := []testCase{
+		{25000, 1001, timecode.Rate{"24.975", 25, 0, 25000, 1001}},
+		{15000, 1001, timecode.Rate{"14.985", 15, 0, 15000, 1001}},
+		{48000, 1001, timecode.Rate{"47.952", 48, 0, 48000, 1001}},
+		{25, 2, timecode.Rate{"12.5", 13, 0, 25, 2}},
+		{120000, 1001, timecode.Rate{"119.88", 120, 8, 120000, 1001}},
+		{90000, 1001, timecode.Rate{"89.91", 90, 0, 90000, 1001}},
+		{100, 1, timecode.Rate{"100", 100, 0, 100, 1}},
+		{1, 2, timecode.Rate{"0.5", 1, 0, 1, 2}},
+	}
+	for _, tc := range cases {
+		rate, err := timecode.RateFromFraction(tc.num, tc.den)
+		require.NoError(t, err)
+		require.Equal(t, tc.rate, rate)
+	}
+}
+
+func TestRateFromFractionInvalidRates(t *testing.T) {
+	cases := [][2]int{{0, 1}, {24, 0}, {-24, 1}, {24, -1}}
+	for _, fraction := range cases {
+		_, err := timecode.RateFromFraction(fraction[0], fraction[1])
+		require.Error(t, err)
+	}
+}
+
 func TestRateFromFractionBuiltinRates(t *testing.T) {
 	cases := []timecode.Rate{
 		timecode.Rate_23_976,
@@ -36,7 +67,149 @@ func TestRateFromFractionBuiltinRates(t *testing.T) {
 		timecode.Rate_59_94,
 	}
 	for _, rate := range cases {
-		newRate := timecode.RateFromFraction(rate.Num, rate.Den)
+		newRate, err := timecode.RateFromFraction(rate.Num, rate.Den)
+		require.NoError(t, err)
 		require.Equal(t, rate, newRate)
 	}
 }
+
+func TestParseRate(t *testing.T) {
+	cases := map[string]timecode.Rate{
+		"23.976": timecode.Rate_23_976,
+		"23.98":  timecode.Rate_23_976,
+		"24":     timecode.Rate_24,
+		"25":     timecode.Rate_25,
+		"29.97":  timecode.Rate_29_97,
+		"30":     timecode.Rate_30,
+		"50":     timecode.Rate_50,
+		"59.94":  timecode.Rate_59_94,
+		"60":     timecode.Rate_60,
+	}
+	for str, rate := range cases {
+		parsed, ok := timecode.ParseRate(str)
+		require.True(t, ok, str)
+		require.Equal(t, rate, parsed, str)
+	}
+
+	_, ok := timecode.ParseRate("fast")
+	require.False(t, ok)
+}
+
+func TestParseRateHints(t *testing.T) {
+	type testCase struct {
+		rate  timecode.Rate
+		hints timecode.RateHints
+	}
+	cases := map[string]testCase{
+		"23.976fps":  {timecode.Rate_23_976, timecode.RateHints{}},
+		"23.98 FPS":  {timecode.Rate_23_976, timecode.RateHints{}},
+		"24000/1001": {timecode.Rate_23_976, timecode.RateHints{}},
+		"48000/2002": {timecode.Rate_23_976, timecode.RateHints{}},
+		"29.970029":  {timecode.Rate_29_97, timecode.RateHints{}},
+		"29.97 DF":   {timecode.Rate_29_97, timecode.RateHints{DropFrame: true}},
+		"29.97-NDF":  {timecode.Rate_29_97, timecode.RateHints{NonDropFrame: true}},
+		"30 NDF":     {timecode.Rate_30, timecode.RateHints{NonDropFrame: true}},
+		"59.94i":     {timecode.Rate_29_97, timecode.RateHints{Interlaced: true}},
+		"59.94i DF":  {timecode.Rate_29_97, timecode.RateHints{Interlaced: true, DropFrame: true}},
+		"50i":        {timecode.Rate_25, timecode.RateHints{Interlaced: true}},
+		"25i":        {timecode.Rate_25, timecode.RateHints{Interlaced: true}},
+		"50p":        {timecode.Rate_50, timecode.RateHints{Progressive: true}},
+		"25PsF":      {timecode.Rate_25, timecode.RateHints{Progressive: true}},
+		"1080i60":    {timecode.Rate_30, timecode.RateHints{Interlaced: true}},
+		"1080i59.94": {timecode.Rate_29_97, timecode.RateHints{Interlaced: true}},
+		"720p59.94":  {timecode.Rate_59_94, timecode.RateHints{Progressive: true}},
+		"60 Hz":      {timecode.Rate_60, timecode.RateHints{}},
+	}
+	for str, tc := range cases {
+		rate, hints, err := timecode.ParseRateHints(str)
+		require.NoError(t, err, str)
+		require.Equal(t, tc.rate, rate, str)
+		require.Equal(t, tc.hints, hints, str)
+	}
+
+	t.Run("nonstandard rates", func(t *testing.T) {
+		rate, _, err := timecode.ParseRateHints("47.952p")
+		require.NoError(t, err)
+		require.Equal(t, 48000, rate.Num)
+		require.Equal(t, 1001, rate.Den)
+
+		rate, _, err = timecode.ParseRateHints("120 fps")
+		require.NoError(t, err)
+		require.Equal(t, 120, rate.Num)
+		require.Equal(t, 1, rate.Den)
+
+		// Whole rates are preferred when they fit at least as well as the 1000/1001 family
+		for str, fraction := range map[string][2]int{
+			"1200":    {1200, 1},
+			"1080":    {1080, 1},
+			"1000fps": {1000, 1},
+			"1198.8":  {1200000, 1001},
+			"12.5":    {25, 2},
+		} {
+			rate, _, err = timecode.ParseRateHints(str)
+			require.NoError(t, err, str)
+			require.Equal(t, fraction, [2]int{rate.Num, rate.Den}, str)
+		}
+	})
+	t.Run("invalid rates", func(t *testing.T) {
+		for _, str := range []string{"", "fps", "0", "24/0", "29.97 XDF", "1080i"} {
+			_, _, err := timecode.ParseRateHints(str)
+			require.Error(t, err, str)
+		}
+	})
+}
+
+func TestRate_Equal(t *testing.T) {
+	require.True(t, timecode.Rate_23_976.Equal(timecode.Rate{"23.98", 24, 0, 24000, 1001}))
+	require.True(t, timecode.Rate_29_97.Equal(timecode.Rate{"29.97", 30, 2, 60000, 2002}))
+	require.False(t, timecode.Rate_29_97.Equal(timecode.Rate_30))
+	require.False(t, timecode.Rate_29_97.Equal(timecode.Rate{"29.97", 30, 0, 30000, 1001}))
+}
+
+func TestRate_Canonical(t *testing.T) {
+	rates := map[timecode.Rate]int{}
+	rates[timecode.Rate{"23.98", 24, 0, 24000, 1001}.Canonical()]++
+	rates[timecode.Rate{"23.976", 24, 0, 48000, 2002}.Canonical()]++
+	rates[timecode.Rate_23_976.Canonical()]++
+	require.Equal(t, map[timecode.Rate]int{timecode.Rate_23_976: 3}, rates)
+
+	// Unregistered rates are normalized and renamed
+	require.Equal(t,
+		timecode.Rate{"47.952", 48, 0, 48000, 1001},
+		timecode.Rate{"48 NTSC", 48, 0, 96000, 2002}.Canonical(),
+	)
+
+	// Invalid rates are left alone
+	require.Equal(t, timecode.Rate{}, timecode.Rate{}.Canonical())
+}
+
+func TestRate_Compare(t *testing.T) {
+	require.Equal(t, -1, timecode.Rate_23_976.Compare(timecode.Rate_24))
+	require.Equal(t, 1, timecode.Rate_30.Compare(timecode.Rate_29_97))
+	require.Equal(t, 0, timecode.Rate_29_97.Compare(timecode.Rate{"29.97", 30, 2, 60000, 2002}))
+	require.Equal(t, 1, timecode.Rate_29_97.Compare(timecode.Rate{"29.97", 30, 0, 30000, 1001}))
+}
+
+func TestRate_Properties(t *testing.T) {
+	type testCase struct {
+		rate                            timecode.Rate
+		dropFrameCapable, integer, ntsc bool
+		durationNum, durationDen        int64
+	}
+	cases := []testCase{
+		{timecode.Rate_23_976, false, false, true, 1001, 24000},
+		{timecode.Rate_24, false, true, false, 1, 24},
+		{timecode.Rate_25, false, true, false, 1, 25},
+		{timecode.Rate_29_97, true, false, true, 1001, 30000},
+		{timecode.Rate_30, false, true, false, 1, 30},
+		{timecode.Rate_59_94, true, false, true, 1001, 60000},
+		{timecode.Rate{"29.97", 30, 2, 60000, 2002}, true, false, true, 1001, 30000},
+		{timecode.Rate{"12.5", 13, 0, 25, 2}, false, false, false, 2, 25},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.dropFrameCapable, tc.rate.IsDropFrameCapable(), tc.rate.String())
+		require.Equal(t, tc.integer, tc.rate.IsInteger(), tc.rate.String())
+		require.Equal(t, tc.ntsc, tc.rate.IsNTSC(), tc.rate.String())
+		require.Equal(t, timecode.NewRational(tc.durationNum, tc.durationDen), tc.rate.FrameDuration(), tc.rate.String())
+	}
+}
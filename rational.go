@@ -0,0 +1,303 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// Rational is an exact rational number, used for converting between frames and seconds without
+// accumulating rounding error. It uses int64 arithmetic while the values fit, and falls back to
+// big integers when they overflow. The zero value is 0.
+type Rational struct {
+	num, den int64
+	big      *big.Rat
+}
+
+// NewRational creates a rational number from a numerator and denominator. It panics if the
+// denominator is zero.
+func NewRational(num, den int64) Rational {
+	if den == 0 {
+		panic("timecode: rational with zero denominator")
+	}
+	if den < 0 {
+		if num == math.MinInt64 || den == math.MinInt64 {
+			return rationalFromBig(new(big.Rat).SetFrac(big.NewInt(num), big.NewInt(den)))
+		}
+		num, den = -num, -den
+	}
+	divisor := gcd64(num, den)
+	return Rational{num: num / divisor, den: den / divisor}
+}
+
+// RationalFromInt creates a rational number from an integer
+func RationalFromInt(n int64) Rational {
+	return Rational{num: n, den: 1}
+}
+
+// RationalFromBig creates a rational number from a big.Rat
+func RationalFromBig(r *big.Rat) Rational {
+	return rationalFromBig(new(big.Rat).Set(r))
+}
+
+// ParseRational parses a rational number from an integer ("3"), a decimal ("29.97") or a
+// fraction ("30000/1001")
+func ParseRational(str string) (Rational, error) {
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return Rational{}, fmt.Errorf("invalid number %q", str)
+	}
+	return rationalFromBig(r), nil
+}
+
+// rationalFromBig creates a rational number that takes ownership of r, using int64
+// arithmetic if it fits
+func rationalFromBig(r *big.Rat) Rational {
+	if r.Num().IsInt64() && r.Denom().IsInt64() {
+		return Rational{num: r.Num().Int64(), den: r.Denom().Int64()}
+	}
+	return Rational{big: r}
+}
+
+// parts gets the numerator and denominator of a small rational number
+func (r Rational) parts() (int64, int64) {
+	if r.den == 0 {
+		return 0, 1
+	}
+	return r.num, r.den
+}
+
+// Big gets the value as a big.Rat
+func (r Rational) Big() *big.Rat {
+	if r.big != nil {
+		return new(big.Rat).Set(r.big)
+	}
+	num, den := r.parts()
+	return big.NewRat(num, den)
+}
+
+// Int64s gets the numerator and denominator in lowest terms, if both of them fit in an int64
+func (r Rational) Int64s() (num, den int64, ok bool) {
+	if r.big != nil {
+		return 0, 0, false
+	}
+	num, den = r.parts()
+	return num, den, true
+}
+
+// Add gets the sum r + other
+func (r Rational) Add(other Rational) Rational {
+	if r.big == nil && other.big == nil {
+		an, ad := r.parts()
+		bn, bd := other.parts()
+		x, ok1 := mul64(an, bd)
+		y, ok2 := mul64(bn, ad)
+		den, ok3 := mul64(ad, bd)
+		if ok1 && ok2 && ok3 {
+			if num, ok := add64(x, y); ok {
+				return NewRational(num, den)
+			}
+		}
+	}
+	return rationalFromBig(new(big.Rat).Add(r.Big(), other.Big()))
+}
+
+// Sub gets the difference r - other
+func (r Rational) Sub(other Rational) Rational {
+	return r.Add(other.Neg())
+}
+
+// Mul gets the product r * other
+func (r Rational) Mul(other Rational) Rational {
+	if r.big == nil && other.big == nil {
+		an, ad := r.parts()
+		bn, bd := other.parts()
+
+		// Cross-reduce first to keep the intermediate values small
+		g1, g2 := gcd64(an, bd), gcd64(bn, ad)
+		num, ok1 := mul64(an/g1, bn/g2)
+		den, ok2 := mul64(ad/g2, bd/g1)
+		if ok1 && ok2 {
+			return NewRational(num, den)
+		}
+	}
+	return rationalFromBig(new(big.Rat).Mul(r.Big(), other.Big()))
+}
+
+// Div gets the quotient r / other. It panics if other is zero.
+func (r Rational) Div(other Rational) Rational {
+	if other.Sign() == 0 {
+		panic("timecode: rational division by zero")
+	}
+	return r.Mul(other.Inv())
+}
+
+// Neg gets the negation -r
+func (r Rational) Neg() Rational {
+	if r.big == nil && r.num != math.MinInt64 {
+		num, den := r.parts()
+		return Rational{num: -num, den: den}
+	}
+	return rationalFromBig(new(big.Rat).Neg(r.Big()))
+}
+
+// Inv gets the inverse 1/r. It panics if r is zero.
+func (r Rational) Inv() Rational {
+	if r.big == nil {
+		num, den := r.parts()
+		return NewRational(den, num)
+	}
+	return rationalFromBig(new(big.Rat).Inv(r.big))
+}
+
+// Cmp compares r and other, and returns -1, 0 or +1
+func (r Rational) Cmp(other Rational) int {
+	if r.big == nil && other.big == nil {
+		an, ad := r.parts()
+		bn, bd := other.parts()
+		x, ok1 := mul64(an, bd)
+		y, ok2 := mul64(bn, ad)
+		if ok1 && ok2 {
+			return compareInt64s(x, y)
+		}
+	}
+	return r.Big().Cmp(other.Big())
+}
+
+// Sign gets the sign of r, which is -1, 0 or +1
+func (r Rational) Sign() int {
+	if r.big != nil {
+		return r.big.Sign()
+	}
+	return compareInt64s(r.num, 0)
+}
+
+// IsInt checks if r is a whole number
+func (r Rational) IsInt() bool {
+	if r.big != nil {
+		return r.big.IsInt()
+	}
+	_, den := r.parts()
+	return den == 1
+}
+
+// Floor rounds r down to the nearest integer. The result must fit in an int64.
+func (r Rational) Floor() int64 {
+	if r.big != nil {
+		return new(big.Int).Div(r.big.Num(), r.big.Denom()).Int64()
+	}
+	num, den := r.parts()
+	quo := num / den
+	if num%den != 0 && num < 0 {
+		quo--
+	}
+	return quo
+}
+
+// Ceil rounds r up to the nearest integer. The result must fit in an int64.
+func (r Rational) Ceil() int64 {
+	return -r.Neg().Floor()
+}
+
+// Round rounds r to the nearest integer, with halves rounded away from zero. The result must fit
+// in an int64.
+func (r Rational) Round() int64 {
+	half := NewRational(1, 2)
+	if r.Sign() < 0 {
+		return -r.Neg().Add(half).Floor()
+	}
+	return r.Add(half).Floor()
+}
+
+// Float64 gets the nearest float64 value of r
+func (r Rational) Float64() float64 {
+	if r.big == nil {
+		num, den := r.parts()
+		return float64(num) / float64(den)
+	}
+	f, _ := r.big.Float64()
+	return f
+}
+
+// FloatString formats r as a decimal with prec digits after the decimal point, with the last
+// digit rounded exactly
+func (r Rational) FloatString(prec int) string {
+	return r.Big().FloatString(prec)
+}
+
+// String formats r as a fraction (ie. "30000/1001"), or as an integer if it's whole
+func (r Rational) String() string {
+	if r.big != nil {
+		return r.big.RatString()
+	}
+	num, den := r.parts()
+	if den == 1 {
+		return strconv.FormatInt(num, 10)
+	}
+	return strconv.FormatInt(num, 10) + "/" + strconv.FormatInt(den, 10)
+}
+
+// durationSeconds converts a duration to an exact number of seconds
+func durationSeconds(d time.Duration) Rational {
+	return NewRational(int64(d), int64(time.Second))
+}
+
+// ceilDuration converts a number of seconds to a duration, rounded up to the next nanosecond
+func ceilDuration(seconds Rational) time.Duration {
+	return time.Duration(seconds.Mul(RationalFromInt(int64(time.Second))).Ceil())
+}
+
+// mul64 multiplies a and b, and reports whether the result fit in an int64
+func mul64(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(abs64(a), abs64(b))
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, false
+	}
+	if (a < 0) != (b < 0) {
+		return -int64(lo), true
+	}
+	return int64(lo), true
+}
+
+// add64 adds a and b, and reports whether the result fit in an int64
+func add64(a, b int64) (int64, bool) {
+	sum := a + b
+	if (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// gcd64 gets the greatest common divisor of a and b. It returns 1 if both are zero, so that the
+// result can always be divided by.
+func gcd64(a, b int64) int64 {
+	x, y := abs64(a), abs64(b)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	if x == 0 {
+		return 1
+	}
+	return int64(x)
+}
+
+func abs64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-x)
+	}
+	return uint64(x)
+}
+
+func compareInt64s(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,102 @@
+package timecode_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRational_Arithmetic(t *testing.T) {
+	a := timecode.NewRational(1001, 30000)
+	b := timecode.NewRational(1, 24)
+	require.Equal(t, "2251/30000", a.Add(b).String())
+	require.Equal(t, "-83/10000", a.Sub(b).String())
+	require.Equal(t, "1001/720000", a.Mul(b).String())
+	require.Equal(t, "1001/1250", a.Div(b).String())
+	require.Equal(t, "30000/1001", a.Inv().String())
+	require.Equal(t, "-1001/30000", a.Neg().String())
+	require.Equal(t, "3", timecode.NewRational(6, 2).String())
+	require.Equal(t, "-1/2", timecode.NewRational(1, -2).String())
+	require.Equal(t, "0", timecode.Rational{}.String())
+}
+
+func TestRational_Compare(t *testing.T) {
+	require.Equal(t, 1, timecode.NewRational(1001, 30000).Cmp(timecode.NewRational(1, 30)))
+	require.Equal(t, -1, timecode.NewRational(1, 30).Cmp(timecode.NewRational(1001, 30000)))
+	require.Equal(t, 0, timecode.NewRational(2, 60).Cmp(timecode.NewRational(1, 30)))
+	require.Equal(t, -1, timecode.NewRational(-1, 2).Sign())
+	require.Equal(t, 0, timecode.Rational{}.Sign())
+	require.True(t, timecode.NewRational(4, 2).IsInt())
+	require.False(t, timecode.NewRational(3, 2).IsInt())
+}
+
+func TestRational_Rounding(t *testing.T) {
+	type testCase struct {
+		r                  timecode.Rational
+		floor, ceil, round int64
+	}
+	cases := []testCase{
+		{timecode.NewRational(7, 2), 3, 4, 4},
+		{timecode.NewRational(-7, 2), -4, -3, -4},
+		{timecode.NewRational(10, 3), 3, 4, 3},
+		{timecode.NewRational(-10, 3), -4, -3, -3},
+		{timecode.RationalFromInt(5), 5, 5, 5},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.floor, tc.r.Floor(), tc.r.String())
+		require.Equal(t, tc.ceil, tc.r.Ceil(), tc.r.String())
+		require.Equal(t, tc.round, tc.r.Round(), tc.r.String())
+	}
+}
+
+func TestRational_Overflow(t *testing.T) {
+	// Values that overflow an int64 fall back to big integers, and return once they fit again
+	huge := timecode.RationalFromInt(math.MaxInt64)
+	product := huge.Mul(timecode.RationalFromInt(4))
+	_, _, ok := product.Int64s()
+	require.False(t, ok)
+	require.Equal(t, new(big.Rat).Mul(big.NewRat(math.MaxInt64, 1), big.NewRat(4, 1)), product.Big())
+
+	quotient := product.Div(timecode.RationalFromInt(8))
+	num, den, ok := quotient.Int64s()
+	require.True(t, ok)
+	require.Equal(t, int64(math.MaxInt64), num)
+	require.Equal(t, int64(2), den)
+
+	sum := huge.Add(huge).Sub(huge)
+	require.Equal(t, 0, sum.Cmp(huge))
+}
+
+func TestParseRational(t *testing.T) {
+	cases := map[string]string{
+		"3":          "3",
+		"29.97":      "2997/100",
+		"30000/1001": "30000/1001",
+		"-1.5":       "-3/2",
+	}
+	for str, expected := range cases {
+		r, err := timecode.ParseRational(str)
+		require.NoError(t, err)
+		require.Equal(t, expected, r.String())
+	}
+
+	_, err := timecode.ParseRational("1/0")
+	require.Error(t, err)
+	_, err = timecode.ParseRational("abc")
+	require.Error(t, err)
+}
+
+func TestTimecode_Seconds(t *testing.T) {
+	// Long programs convert exactly
+	tc := timecode.MustParse("23:59:59;29", timecode.Rate_29_97)
+	require.Equal(t, "2591996407/30000", tc.Seconds().String())
+	require.Equal(t, tc.Frame(), timecode.FromSeconds(tc.Seconds(), timecode.Rate_29_97, true).Frame())
+
+	// Times within a frame belong to that frame
+	seconds := timecode.NewRational(3600, 1).Add(timecode.NewRational(1, 100))
+	require.Equal(t, "01:00:00:00", timecode.FromSeconds(seconds, timecode.Rate_24, false).String())
+	require.Equal(t, "01:00:00:01", timecode.FromSeconds(seconds.Add(timecode.NewRational(1, 24)), timecode.Rate_24, false).String())
+}
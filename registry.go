@@ -0,0 +1,119 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// rateRegistry holds the rates that are recognized by ParseRate and RateFromFraction
+type rateRegistry struct {
+	mu         sync.RWMutex
+	rates      []Rate
+	byName     map[string]Rate
+	byFraction map[[2]int]Rate
+}
+
+// builtinRates are the rates that are always known, along with their aliases
+var builtinRates = map[Rate][]string{
+	Rate_23_976: {"23.98"},
+	Rate_24:     nil,
+	Rate_25:     nil,
+	Rate_29_97:  nil,
+	Rate_30:     nil,
+	Rate_50:     nil,
+	Rate_59_94:  nil,
+	Rate_60:     nil,
+}
+
+// registry is the registry of known rates, which starts out with the built-in rates
+var registry = newRateRegistry(builtinRates)
+
+func newRateRegistry(rates map[Rate][]string) *rateRegistry {
+	r := &rateRegistry{
+		byName:     make(map[string]Rate),
+		byFraction: make(map[[2]int]Rate),
+	}
+	for rate, aliases := range rates {
+		if err := r.register(rate, aliases); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// RegisterRate registers a custom rate, so that it's recognized by ParseRate using its Str or any of the
+// aliases, and by RateFromFraction using its fraction. Names are matched without regard to case. An error
+// is returned if the rate is invalid, or if its names or fraction are already registered.
+func RegisterRate(rate Rate, aliases ...string) error {
+	return registry.register(rate, aliases)
+}
+
+// Rates gets all the known rates, including custom rates, ordered from slowest to fastest
+func Rates() []Rate {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return append([]Rate(nil), registry.rates...)
+}
+
+func (r *rateRegistry) register(rate Rate, aliases []string) error {
+	if rate.Str == "" {
+		return errors.New("rate must have a name")
+	}
+	if rate.Num <= 0 || rate.Den <= 0 || rate.Nominal <= 0 || rate.Drop < 0 || rate.Drop >= rate.Nominal {
+		return fmt.Errorf("rate %s is invalid", rate.Str)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Make sure none of the names or the fraction are taken
+	names := append([]string{rate.Str}, aliases...)
+	for i, name := range names {
+		names[i] = strings.ToLower(name)
+		if _, ok := r.byName[names[i]]; ok {
+			return fmt.Errorf("rate %s is already registered", name)
+		}
+	}
+	fraction := reducedFraction(rate.Num, rate.Den)
+	if existing, ok := r.byFraction[fraction]; ok {
+		return fmt.Errorf("rate %d/%d is already registered as %s", rate.Num, rate.Den, existing.Str)
+	}
+
+	// Add the rate, keeping the rates ordered
+	for _, name := range names {
+		r.byName[name] = rate
+	}
+	r.byFraction[fraction] = rate
+	r.rates = append(r.rates, rate)
+	sort.Slice(r.rates, func(i, j int) bool {
+		return r.rates[i].Compare(r.rates[j]) < 0
+	})
+	return nil
+}
+
+// lookupName finds the rate registered with the given name or alias
+func (r *rateRegistry) lookupName(name string) (Rate, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rate, ok := r.byName[strings.ToLower(name)]
+	return rate, ok
+}
+
+// lookupFraction finds the rate registered with a fraction equivalent to num/den
+func (r *rateRegistry) lookupFraction(num, den int) (Rate, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rate, ok := r.byFraction[reducedFraction(num, den)]
+	return rate, ok
+}
+
+// reducedFraction reduces num/den to its lowest terms
+func reducedFraction(num, den int) [2]int {
+	if divisor := gcd(num, den); divisor != 0 {
+		return [2]int{num / divisor, den / divisor}
+	}
+	return [2]int{num, den}
+}
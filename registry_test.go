@@ -0,0 +1,53 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRates(t *testing.T) {
+	rates := timecode.Rates()
+	require.Subset(t, rates, []timecode.Rate{
+		timecode.Rate_23_976,
+		timecode.Rate_24,
+		timecode.Rate_25,
+		timecode.Rate_29_97,
+		timecode.Rate_30,
+		timecode.Rate_59_94,
+		timecode.Rate_60,
+	})
+	for i := 1; i < len(rates); i++ {
+		require.LessOrEqual(t, rates[i-1].Num*rates[i].Den, rates[i].Num*rates[i-1].Den)
+	}
+}
+
+func TestRegisterRate(t *testing.T) {
+	timecode.UseTestRegistry(t)
+	rate := timecode.Rate{Str: "16", Nominal: 16, Drop: 0, Num: 16, Den: 1}
+	require.NoError(t, timecode.RegisterRate(rate, "16fps", "Silent"))
+	require.Contains(t, timecode.Rates(), rate)
+
+	// The rate is recognized by its names and fraction
+	for _, name := range []string{"16", "16fps", "silent"} {
+		parsed, ok := timecode.ParseRate(name)
+		require.True(t, ok, name)
+		require.Equal(t, rate, parsed)
+	}
+	for _, fraction := range [][2]int{{16, 1}, {32, 2}} {
+		fromFraction, err := timecode.RateFromFraction(fraction[0], fraction[1])
+		require.NoError(t, err)
+		require.Equal(t, rate, fromFraction)
+	}
+
+	// Names and fractions can't be registered twice
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Str: "silent", Nominal: 18, Num: 18, Den: 1}))
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Str: "sixteen", Nominal: 16, Num: 16, Den: 1}))
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Str: "29.97 NDF", Nominal: 30, Num: 30000, Den: 1001}))
+
+	// Invalid rates can't be registered
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Nominal: 18, Num: 18, Den: 1}))
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Str: "18", Nominal: 18, Num: 18, Den: 0}))
+	require.Error(t, timecode.RegisterRate(timecode.Rate{Str: "18", Nominal: 18, Drop: 18, Num: 18, Den: 1}))
+}
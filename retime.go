@@ -0,0 +1,117 @@
+package timecode
+
+import (
+	"sort"
+)
+
+// TimeMap maps a number of frames into a record timeline to a position in the source, in source frames.
+// Positions can fall between source frames, and are resolved to a frame using a FrameSampling.
+type TimeMap interface {
+	SourcePosition(record int64) Rational
+}
+
+// ConstantSpeed is a time map that plays the source at a constant speed, as a ratio of source frames to
+// record frames (ie. 1/2 for 50% slow motion). Negative speeds play the source in reverse.
+type ConstantSpeed struct {
+	Speed Rational
+}
+
+// Reverse is a time map that plays the source in reverse at normal speed
+var Reverse = ConstantSpeed{RationalFromInt(-1)}
+
+// M2Speed creates a constant speed time map from the frames per second of an EDL motion effect (M2),
+// on a record timeline at the given rate
+func M2Speed(fps Rational, rate Rate) ConstantSpeed {
+	return ConstantSpeed{fps.Div(RationalFromInt(int64(rate.Nominal)))}
+}
+
+// SourcePosition gets the source position of a record frame
+func (c ConstantSpeed) SourcePosition(record int64) Rational {
+	return c.Speed.Mul(RationalFromInt(record))
+}
+
+// FreezeFrame is a time map that holds the first source frame
+type FreezeFrame struct{}
+
+// SourcePosition gets the source position of a record frame
+func (FreezeFrame) SourcePosition(record int64) Rational {
+	return Rational{}
+}
+
+// Keyframe pins a number of frames into the record timeline to a position in the source
+type Keyframe struct {
+	Record int64
+	Source Rational
+}
+
+// Keyframes is a time map that interpolates linearly between keyframes, for variable speed effects. The
+// source is held at the first keyframe before it, and at the last keyframe after it. Keyframes must be
+// ordered by their record frame, which NewKeyframes takes care of.
+type Keyframes []Keyframe
+
+// NewKeyframes creates a keyframes time map, ordering the keyframes by their record frame
+func NewKeyframes(keyframes ...Keyframe) Keyframes {
+	sorted := append(Keyframes(nil), keyframes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Record < sorted[j].Record })
+	return sorted
+}
+
+// SourcePosition gets the source position of a record frame
+func (k Keyframes) SourcePosition(record int64) Rational {
+	if len(k) == 0 {
+		return Rational{}
+	}
+
+	// Find the first keyframe after the record frame
+	i := sort.Search(len(k), func(i int) bool { return k[i].Record > record })
+	switch i {
+	case 0:
+		return k[0].Source
+	case len(k):
+		return k[len(k)-1].Source
+	}
+
+	// Interpolate between the keyframes on either side
+	from, to := k[i-1], k[i]
+	progress := NewRational(record-from.Record, to.Record-from.Record)
+	return from.Source.Add(to.Source.Sub(from.Source).Mul(progress))
+}
+
+// FrameSampling is a rule for choosing the source frame shown at a source position between frames
+type FrameSampling int
+
+const (
+	// SampleFloor shows the source frame that contains the position
+	SampleFloor FrameSampling = iota
+	// SampleNearest shows the source frame that starts closest to the position
+	SampleNearest
+	// SampleCeil shows the first source frame that starts at or after the position
+	SampleCeil
+)
+
+// sample resolves a source position to a source frame
+func (s FrameSampling) sample(position Rational) int64 {
+	switch s {
+	case SampleNearest:
+		return position.Round()
+	case SampleCeil:
+		return position.Ceil()
+	default:
+		return position.Floor()
+	}
+}
+
+// Retime maps record timecodes to source timecodes for a retimed clip, such as a speed change or
+// freeze frame. The time map is relative to the record and source in points.
+type Retime struct {
+	RecordIn *Timecode
+	SourceIn *Timecode
+	Map      TimeMap
+	Sampling FrameSampling
+}
+
+// Source gets the source timecode shown at a record timecode
+func (r *Retime) Source(record *Timecode) *Timecode {
+	position := r.Map.SourcePosition(record.Frame() - r.RecordIn.Frame())
+	return r.SourceIn.AddFrames(r.Sampling.sample(position))
+}
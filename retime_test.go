@@ -0,0 +1,108 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func retimeSources(retime *timecode.Retime, frames int) []string {
+	var sources []string
+	for i := 0; i < frames; i++ {
+		sources = append(sources, retime.Source(retime.RecordIn.AddFrames(int64(i))).String())
+	}
+	return sources
+}
+
+func TestRetime_ConstantSpeed(t *testing.T) {
+	retime := &timecode.Retime{
+		RecordIn: timecode.MustParse("01:00:00:00", timecode.Rate_24),
+		SourceIn: timecode.MustParse("10:00:00:00", timecode.Rate_24),
+		Map:      timecode.ConstantSpeed{Speed: timecode.NewRational(1, 2)},
+	}
+	require.Equal(t, []string{
+		"10:00:00:00",
+		"10:00:00:00",
+		"10:00:00:01",
+		"10:00:00:01",
+		"10:00:00:02",
+	}, retimeSources(retime, 5))
+
+	retime.Sampling = timecode.SampleNearest
+	require.Equal(t, []string{
+		"10:00:00:00",
+		"10:00:00:01",
+		"10:00:00:01",
+		"10:00:00:02",
+		"10:00:00:02",
+	}, retimeSources(retime, 5))
+
+	retime.Sampling = timecode.SampleCeil
+	retime.Map = timecode.ConstantSpeed{Speed: timecode.NewRational(2, 3)}
+	require.Equal(t, []string{
+		"10:00:00:00",
+		"10:00:00:01",
+		"10:00:00:02",
+		"10:00:00:02",
+	}, retimeSources(retime, 4))
+}
+
+func TestRetime_M2Speed(t *testing.T) {
+	// An M2 of 48 fps on a 24 fps timeline is double speed
+	retime := &timecode.Retime{
+		RecordIn: timecode.MustParse("01:00:00:00", timecode.Rate_24),
+		SourceIn: timecode.MustParse("10:00:00:00", timecode.Rate_24),
+		Map:      timecode.M2Speed(timecode.RationalFromInt(48), timecode.Rate_24),
+	}
+	require.Equal(t, "10:00:02:00", retime.Source(timecode.MustParse("01:00:01:00", timecode.Rate_24)).String())
+
+	// An M2 of -29.97 fps on a 29.97 timeline is reverse
+	retime = &timecode.Retime{
+		RecordIn: timecode.MustParse("01:00:00;00", timecode.Rate_29_97),
+		SourceIn: timecode.MustParse("10:00:00;10", timecode.Rate_29_97),
+		Map:      timecode.M2Speed(timecode.NewRational(-2997, 100), timecode.Rate_29_97),
+	}
+	require.Equal(t, "10:00:00;01", retime.Source(timecode.MustParse("01:00:00;09", timecode.Rate_29_97)).String())
+}
+
+func TestRetime_FreezeAndReverse(t *testing.T) {
+	retime := &timecode.Retime{
+		RecordIn: timecode.MustParse("01:00:00:00", timecode.Rate_25),
+		SourceIn: timecode.MustParse("10:00:00:10", timecode.Rate_25),
+		Map:      timecode.FreezeFrame{},
+	}
+	require.Equal(t, []string{"10:00:00:10", "10:00:00:10", "10:00:00:10"}, retimeSources(retime, 3))
+
+	retime.Map = timecode.Reverse
+	require.Equal(t, []string{"10:00:00:10", "10:00:00:09", "10:00:00:08"}, retimeSources(retime, 3))
+}
+
+func TestRetime_Keyframes(t *testing.T) {
+	retime := &timecode.Retime{
+		RecordIn: timecode.MustParse("01:00:00:00", timecode.Rate_24),
+		SourceIn: timecode.MustParse("10:00:00:00", timecode.Rate_24),
+		Map: timecode.NewKeyframes(
+			timecode.Keyframe{Record: 8, Source: timecode.RationalFromInt(10)},
+			timecode.Keyframe{Record: 0, Source: timecode.RationalFromInt(0)},
+			timecode.Keyframe{Record: 4, Source: timecode.RationalFromInt(8)},
+		),
+	}
+	require.Equal(t, []string{
+		"10:00:00:00",
+		"10:00:00:02",
+		"10:00:00:04",
+		"10:00:00:06",
+		"10:00:00:08",
+		"10:00:00:08",
+		"10:00:00:09",
+		"10:00:00:09",
+		"10:00:00:10",
+		"10:00:00:10",
+	}, retimeSources(retime, 10))
+
+	// Record frames before the first keyframe hold the first keyframe
+	retime.RecordIn = retime.RecordIn.AddFrames(2)
+	require.Equal(t, "10:00:00:00", retime.Source(timecode.MustParse("01:00:00:00", timecode.Rate_24)).String())
+	require.Equal(t, "0", timecode.Keyframes{}.SourcePosition(5).String())
+}
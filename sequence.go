@@ -0,0 +1,175 @@
+package timecode
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sequenceStemRegex matches the frame number at the end of a filename without its extension
+var sequenceStemRegex = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// SequenceFile is the name of a file in an image sequence, such as shot_0086400.exr
+type SequenceFile struct {
+	Prefix    string
+	Frame     int64
+	Padding   int
+	Extension string
+}
+
+// ParseSequenceFile parses the name of a file in an image sequence. The frame number is the last number in
+// the name before the extension. Any directory is removed from the name.
+func ParseSequenceFile(name string) (SequenceFile, error) {
+	name = filepath.Base(name)
+	stem, ext := name, filepath.Ext(name)
+	if _, err := strconv.ParseUint(strings.TrimPrefix(ext, "."), 10, 64); ext != "" && err != nil {
+		stem = name[:len(name)-len(ext)]
+	} else {
+		ext = ""
+	}
+	match := sequenceStemRegex.FindStringSubmatch(stem)
+	if match == nil {
+		return SequenceFile{}, fmt.Errorf("%s has no frame number", name)
+	}
+	frame, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return SequenceFile{}, fmt.Errorf("%s has an invalid frame number", name)
+	}
+	return SequenceFile{
+		Prefix:    match[1],
+		Frame:     frame,
+		Padding:   len(match[2]),
+		Extension: ext,
+	}, nil
+}
+
+// String creates the filename, padding the frame number with zeros
+func (f SequenceFile) String() string {
+	return fmt.Sprintf("%s%0*d%s", f.Prefix, f.Padding, f.Frame, f.Extension)
+}
+
+// ImageSequence maps the frame numbers in the filenames of an image sequence, such as a DPX or EXR
+// sequence, to timecodes
+type ImageSequence struct {
+	Prefix    string
+	Padding   int
+	Extension string
+
+	Rate      Rate
+	DropFrame bool
+
+	// Offset is added to a frame number to get the frame of its timecode
+	Offset int64
+}
+
+// NewImageSequence creates an image sequence from the name of one of its files and the timecode of that
+// file. The sequence has the rate and drop frame mode of the timecode.
+func NewImageSequence(name string, tc *Timecode) (*ImageSequence, error) {
+	file, err := ParseSequenceFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageSequence{
+		Prefix:    file.Prefix,
+		Padding:   file.Padding,
+		Extension: file.Extension,
+		Rate:      tc.rate,
+		DropFrame: tc.dropFrame,
+		Offset:    tc.frame - file.Frame,
+	}, nil
+}
+
+// Timecode gets the timecode of a frame number
+func (s *ImageSequence) Timecode(frame int64) *Timecode {
+	return FromFrame(frame+s.Offset, s.Rate, s.DropFrame)
+}
+
+// FrameNumber gets the frame number of a timecode or frame
+func (s *ImageSequence) FrameNumber(frame Framer) int64 {
+	return frame.Frame() - s.Offset
+}
+
+// Filename gets the name of the file with a frame number
+func (s *ImageSequence) Filename(frame int64) string {
+	return SequenceFile{s.Prefix, frame, s.Padding, s.Extension}.String()
+}
+
+// FileTimecode gets the timecode of a file in the sequence. An error is returned if the file isn't part
+// of the sequence.
+func (s *ImageSequence) FileTimecode(name string) (*Timecode, error) {
+	file, err := ParseSequenceFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.contains(file) {
+		return nil, fmt.Errorf("%s is not part of the sequence", name)
+	}
+	return s.Timecode(file.Frame), nil
+}
+
+// Filenames gets the names of the files from in up to, but not including, out
+func (s *ImageSequence) Filenames(in, out Framer) []string {
+	var names []string
+	for frame := s.FrameNumber(in); frame < s.FrameNumber(out); frame++ {
+		names = append(names, s.Filename(frame))
+	}
+	return names
+}
+
+// contains checks if a file is part of the sequence. Frame numbers with more digits than the padding are
+// part of the sequence, as long as they aren't padded further.
+func (s *ImageSequence) contains(file SequenceFile) bool {
+	digits := len(strconv.FormatInt(file.Frame, 10))
+	return file.Prefix == s.Prefix &&
+		file.Extension == s.Extension &&
+		(file.Padding == s.Padding || file.Padding > s.Padding && file.Padding == digits)
+}
+
+// FrameRange is a range of frame numbers, from First to Last inclusive
+type FrameRange struct {
+	First, Last int64
+}
+
+// SequenceListing is the result of scanning a directory listing for the files of an image sequence
+type SequenceListing struct {
+	// Frames are the frame numbers found, in order
+	Frames []int64
+	// Gaps are the ranges of missing frame numbers between the first and last frames
+	Gaps []FrameRange
+	// Duplicates are the names of the files for frame numbers with more than one file, such as
+	// shot_86400.exr and shot_086400.exr
+	Duplicates map[int64][]string
+}
+
+// Scan finds the files of the sequence in a directory listing, along with any gaps and duplicates. Names
+// that aren't part of the sequence are ignored. Unlike the other methods, files with any padding are
+// included, so that files with inconsistent padding are found as duplicates.
+func (s *ImageSequence) Scan(names []string) SequenceListing {
+	files := make(map[int64][]string)
+	for _, name := range names {
+		file, err := ParseSequenceFile(name)
+		if err != nil || file.Prefix != s.Prefix || file.Extension != s.Extension {
+			continue
+		}
+		files[file.Frame] = append(files[file.Frame], name)
+	}
+
+	listing := SequenceListing{Duplicates: make(map[int64][]string)}
+	for frame, names := range files {
+		listing.Frames = append(listing.Frames, frame)
+		if len(names) > 1 {
+			sort.Strings(names)
+			listing.Duplicates[frame] = names
+		}
+	}
+	sort.Slice(listing.Frames, func(i, j int) bool { return listing.Frames[i] < listing.Frames[j] })
+	for i := 1; i < len(listing.Frames); i++ {
+		if prev, frame := listing.Frames[i-1], listing.Frames[i]; frame > prev+1 {
+			listing.Gaps = append(listing.Gaps, FrameRange{prev + 1, frame - 1})
+		}
+	}
+	return listing
+}
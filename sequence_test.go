@@ -0,0 +1,89 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSequenceFile(t *testing.T) {
+	cases := map[string]timecode.SequenceFile{
+		"shot_0086400.exr":              {Prefix: "shot_", Frame: 86400, Padding: 7, Extension: ".exr"},
+		"/renders/shot.1001.dpx":        {Prefix: "shot.", Frame: 1001, Padding: 4, Extension: ".dpx"},
+		"v2_shot_001.jp2":               {Prefix: "v2_shot_", Frame: 1, Padding: 3, Extension: ".jp2"},
+		"plate.0001":                    {Prefix: "plate.", Frame: 1, Padding: 4},
+		"12345.tif":                     {Frame: 12345, Padding: 5, Extension: ".tif"},
+		"A001C003_220101_R1AB.0042.ari": {Prefix: "A001C003_220101_R1AB.", Frame: 42, Padding: 4, Extension: ".ari"},
+	}
+	for name, expected := range cases {
+		file, err := timecode.ParseSequenceFile(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, file, name)
+	}
+	require.Equal(t, "shot_0086400.exr", timecode.SequenceFile{Prefix: "shot_", Frame: 86400, Padding: 7, Extension: ".exr"}.String())
+	require.Equal(t, "shot_12345.exr", timecode.SequenceFile{Prefix: "shot_", Frame: 12345, Padding: 3, Extension: ".exr"}.String())
+
+	for _, name := range []string{"shot.exr", "", "shot_99999999999999999999.exr"} {
+		_, err := timecode.ParseSequenceFile(name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestImageSequence(t *testing.T) {
+	// Frame numbers that are the timecode frame counts
+	seq, err := timecode.NewImageSequence("shot_0086400.exr", timecode.MustParse("01:00:00:00", timecode.Rate_24))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), seq.Offset)
+	require.Equal(t, "01:00:01:00", seq.Timecode(86424).String())
+
+	tc, err := seq.FileTimecode("/renders/shot_0086430.exr")
+	require.NoError(t, err)
+	require.Equal(t, "01:00:01:06", tc.String())
+	for _, name := range []string{"shot_086430.exr", "shot_00086430.exr", "shot_0086430.dpx", "plate_0086430.exr", "shot.exr"} {
+		_, err := seq.FileTimecode(name)
+		require.Error(t, err, name)
+	}
+
+	// Frame numbers that start at 1001
+	seq, err = timecode.NewImageSequence("shot.1001.dpx", timecode.MustParse("00:59:59;28", timecode.Rate_29_97))
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00;00", seq.Timecode(1003).String())
+	require.Equal(t, int64(1003), seq.FrameNumber(timecode.MustParse("01:00:00;00", timecode.Rate_29_97)))
+	require.Equal(t, "shot.1003.dpx", seq.Filename(1003))
+	require.Equal(t, []string{"shot.1002.dpx", "shot.1003.dpx", "shot.1004.dpx"}, seq.Filenames(
+		timecode.MustParse("00:59:59;29", timecode.Rate_29_97),
+		timecode.MustParse("01:00:00;02", timecode.Rate_29_97),
+	))
+
+	// Frame numbers that outgrow the padding
+	seq = &timecode.ImageSequence{Prefix: "f", Padding: 4, Extension: ".exr", Rate: timecode.Rate_25}
+	tc, err = seq.FileTimecode("f12345.exr")
+	require.NoError(t, err)
+	require.Equal(t, int64(12345), tc.Frame())
+	require.Equal(t, "f12345.exr", seq.Filename(12345))
+}
+
+func TestImageSequence_Scan(t *testing.T) {
+	seq := &timecode.ImageSequence{Prefix: "shot_", Padding: 4, Extension: ".exr", Rate: timecode.Rate_24}
+	listing := seq.Scan([]string{
+		"shot_0005.exr",
+		"shot_0001.exr",
+		"shot_0002.exr",
+		"shot_002.exr",
+		"shot_0009.exr",
+		"shot_0003.exr",
+		"shot_0006.exr",
+		"shot_0004.dpx",
+		"notes.txt",
+		"plate_0004.exr",
+	})
+	require.Equal(t, []int64{1, 2, 3, 5, 6, 9}, listing.Frames)
+	require.Equal(t, []timecode.FrameRange{{4, 4}, {7, 8}}, listing.Gaps)
+	require.Equal(t, map[int64][]string{2: {"shot_0002.exr", "shot_002.exr"}}, listing.Duplicates)
+
+	listing = seq.Scan(nil)
+	require.Empty(t, listing.Frames)
+	require.Empty(t, listing.Gaps)
+	require.Empty(t, listing.Duplicates)
+}
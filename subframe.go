@@ -0,0 +1,54 @@
+package timecode
+
+// Sub-frame resolutions, in sub-frames per frame
+const (
+	// SubframeResolution_80 is the resolution of sub-frames in MIDI and SMPTE 12M bi-phase timecode
+	SubframeResolution_80 = 80
+	// SubframeResolution_100 is the resolution of sub-frames shown by Pro Tools
+	SubframeResolution_100 = 100
+	// DefaultSubframeResolution is the resolution used when parsing timecodes with sub-frames
+	DefaultSubframeResolution = SubframeResolution_100
+)
+
+// newSubframeTimecode creates a timecode with sub-frames, carrying whole frames from the sub-frames into
+// the frames so that the sub-frame is always within the frame
+func newSubframeTimecode(frame, subframe int64, resolution int, rate Rate, dropFrame bool) *Timecode {
+	carry := NewRational(subframe, int64(resolution)).Floor()
+	return &Timecode{
+		frame:              frame + carry,
+		rate:               rate,
+		dropFrame:          dropFrame,
+		subframe:           subframe - carry*int64(resolution),
+		subframeResolution: resolution,
+	}
+}
+
+// Subframe gets the position within the frame, in sub-frames
+func (t *Timecode) Subframe() int64 {
+	return t.subframe
+}
+
+// SubframeResolution gets the number of sub-frames per frame, which is zero if the timecode has no
+// sub-frame component
+func (t *Timecode) SubframeResolution() int {
+	return t.subframeResolution
+}
+
+// WithSubframe creates a copy of this timecode with the given sub-frame position and resolution. Sub-frames
+// beyond the frame are carried into the frames. A resolution of zero removes the sub-frame component.
+func (t *Timecode) WithSubframe(subframe int64, resolution int) *Timecode {
+	if resolution <= 0 {
+		return FromFrame(t.frame, t.rate, t.dropFrame)
+	}
+	return newSubframeTimecode(t.frame, subframe, resolution, t.rate, t.dropFrame)
+}
+
+// AddSubframes adds sub-frames to this timecode, carrying them into the frames. Timecodes without a
+// sub-frame component get one at the default resolution.
+func (t *Timecode) AddSubframes(subframes int64) *Timecode {
+	resolution := t.subframeResolution
+	if resolution == 0 {
+		resolution = DefaultSubframeResolution
+	}
+	return newSubframeTimecode(t.frame, t.subframe+subframes, resolution, t.rate, t.dropFrame)
+}
@@ -0,0 +1,98 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Subframes(t *testing.T) {
+	tc := timecode.MustParse("01:00:00:12.42", timecode.Rate_24)
+	require.Equal(t, "01:00:00:12.42", tc.String())
+	require.Equal(t, int64(86412), tc.Frame())
+	require.Equal(t, int64(42), tc.Subframe())
+	require.Equal(t, timecode.DefaultSubframeResolution, tc.SubframeResolution())
+
+	tc = timecode.MustParse("00:01:00;02.07", timecode.Rate_29_97)
+	require.Equal(t, "00:01:00;02.07", tc.String())
+
+	tc, err := timecode.ParseSubframes("01:00:00:12.79", timecode.Rate_25, timecode.SubframeResolution_80)
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00:12.79", tc.String())
+	require.Equal(t, timecode.SubframeResolution_80, tc.SubframeResolution())
+
+	_, err = timecode.ParseSubframes("01:00:00:12.80", timecode.Rate_25, timecode.SubframeResolution_80)
+	require.Error(t, err)
+
+	// Timecodes without sub-frames don't get a sub-frame component
+	tc = timecode.MustParse("01:00:00:12", timecode.Rate_24)
+	require.Equal(t, "01:00:00:12", tc.String())
+	require.Equal(t, 0, tc.SubframeResolution())
+}
+
+func TestTimecode_SubframeArithmetic(t *testing.T) {
+	t.Run("add sub-frames", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:00:23.90", timecode.Rate_24)
+		require.Equal(t, "00:00:01:00.05", tc.AddSubframes(15).String())
+		require.Equal(t, "00:00:00:23.80", tc.AddSubframes(-10).String())
+		require.Equal(t, "00:00:00:22.95", tc.AddSubframes(-95).String())
+		require.Equal(t, "00:00:00:00.50", timecode.FromFrame(0, timecode.Rate_24, false).AddSubframes(50).String())
+	})
+	t.Run("add frames keeps sub-frames", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:59;29.50", timecode.Rate_29_97)
+		require.Equal(t, "00:01:00;02.50", tc.AddFrames(1).String())
+	})
+	t.Run("add timecodes with sub-frames", func(t *testing.T) {
+		a := timecode.MustParse("00:00:01:00.60", timecode.Rate_24)
+		b, err := timecode.ParseSubframes("00:00:00:01.40", timecode.Rate_24, timecode.SubframeResolution_80)
+		require.NoError(t, err)
+		require.Equal(t, "00:00:01:02.10", a.Add(b).String())
+
+		// Sub-frames at another resolution are rounded to the nearest sub-frame
+		for subframe, expected := range map[int64]string{
+			1:  "00:00:01:00.61",
+			2:  "00:00:01:00.63",
+			3:  "00:00:01:00.64",
+			-1: "00:00:01:00.59",
+		} {
+			b := timecode.FromFrame(0, timecode.Rate_24, false).WithSubframe(subframe, timecode.SubframeResolution_80)
+			require.Equal(t, expected, a.Add(b).String(), subframe)
+			require.Equal(t, expected, a.Sub(timecode.FromFrame(0, timecode.Rate_24, false).WithSubframe(-subframe, timecode.SubframeResolution_80)).String(), subframe)
+		}
+	})
+	t.Run("sub timecodes with sub-frames", func(t *testing.T) {
+		a := timecode.MustParse("01:00:00:00.50", timecode.Rate_25)
+		require.Equal(t, "00:59:59:24.75", a.Sub(timecode.MustParse("00:00:00:00.75", timecode.Rate_25)).String())
+		require.Equal(t, "00:59:59:24.50", a.Sub(timecode.MustParse("00:00:00:01", timecode.Rate_25)).String())
+		require.Equal(t, "01:00:00:00.00", a.Sub(timecode.MustParse("00:00:00:00.50", timecode.Rate_25)).String())
+	})
+	t.Run("with sub-frame", func(t *testing.T) {
+		tc := timecode.MustParse("00:00:01:00", timecode.Rate_24)
+		require.Equal(t, "00:00:01:00.79", tc.WithSubframe(79, timecode.SubframeResolution_80).String())
+		require.Equal(t, "00:00:01:01.00", tc.WithSubframe(80, timecode.SubframeResolution_80).String())
+		require.Equal(t, "00:00:01:00", tc.WithSubframe(79, timecode.SubframeResolution_80).WithSubframe(0, 0).String())
+	})
+}
+
+func TestTimecode_SubframeSeconds(t *testing.T) {
+	tc := timecode.MustParse("01:00:00:00.50", timecode.Rate_25)
+	require.Equal(t, timecode.NewRational(180001, 50), tc.Seconds())
+
+	// Sub-frames at different resolutions are equal when they're at the same position
+	a := timecode.MustParse("01:00:00:00.50", timecode.Rate_25)
+	b, err := timecode.ParseSubframes("01:00:00:00.40", timecode.Rate_25, timecode.SubframeResolution_80)
+	require.NoError(t, err)
+	require.True(t, a.Equals(b))
+	require.False(t, a.Equals(timecode.MustParse("01:00:00:00", timecode.Rate_25)))
+
+	// Timecodes are compared by their position in frames, regardless of their rates, whether or not they
+	// have sub-frames
+	at24, at25 := timecode.FromFrame(24, timecode.Rate_24, false), timecode.FromFrame(24, timecode.Rate_25, false)
+	require.True(t, at24.Equals(at25))
+	require.True(t, at24.Equals(at25.WithSubframe(0, 100)))
+	require.True(t, at24.WithSubframe(40, 80).Equals(at25.WithSubframe(50, 100)))
+	require.False(t, at24.Equals(at25.WithSubframe(1, 100)))
+	require.True(t, at24.WithSubframe(0, 100).Equals(timecode.Frame(24)))
+	require.False(t, at24.WithSubframe(1, 100).Equals(timecode.Frame(24)))
+}
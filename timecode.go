@@ -20,6 +20,17 @@ type Timecode struct {
 	frame     int64
 	rate      Rate
 	dropFrame bool
+
+	// subframe is the position within the frame, in units of 1/subframeResolution of a frame. The
+	// resolution is zero if the timecode has no sub-frame component.
+	subframe           int64
+	subframeResolution int
+
+	// field is the interlaced field (1 or 2) of the frame, or zero if the timecode has no field
+	field int
+
+	// components caches the components of the timecode, if they were known when it was created
+	components *Components
 }
 
 // Frame gets the frame index for this timecode
@@ -27,6 +38,29 @@ func (t *Timecode) Frame() int64 {
 	return t.frame
 }
 
+// Rate gets the frame rate of this timecode
+func (t *Timecode) Rate() Rate {
+	return t.rate
+}
+
+// DropFrame checks if this timecode is a drop frame timecode
+func (t *Timecode) DropFrame() bool {
+	return t.dropFrame
+}
+
+// Seconds gets the exact time in seconds of this timecode, including any sub-frames or field
+func (t *Timecode) Seconds() Rational {
+	seconds := t.rate.Seconds(t.frame)
+	if t.subframeResolution > 0 {
+		subframes := NewRational(t.subframe, int64(t.subframeResolution))
+		seconds = seconds.Add(subframes.Mul(t.rate.FrameDuration()))
+	}
+	if t.field == 2 {
+		seconds = seconds.Add(NewRational(1, 2).Mul(t.rate.FrameDuration()))
+	}
+	return seconds
+}
+
 func (t *Timecode) componentsNDF(frame int64) Components {
 	// Track the remaining frames
 	frames := frame % int64(t.rate.Nominal)
@@ -83,6 +117,9 @@ func (t *Timecode) componentsDF(frame int64) Components {
 
 // Components gets the components of the timecode: hours, minutes, seconds, frames.
 func (t *Timecode) Components() Components {
+	if t.components != nil {
+		return *t.components
+	}
 	if !t.dropFrame {
 		return t.componentsNDF(t.frame)
 	} else {
@@ -90,8 +127,14 @@ func (t *Timecode) Components() Components {
 	}
 }
 
-// String creates a string representation for the timecode
+// String creates a string representation for the timecode. The second field of an interlaced timecode is
+// marked as described by FieldMarkString, so that it's not confused with sub-frames.
 func (t *Timecode) String() string {
+	// Mark the second field using the final separator, which can't be confused with sub-frames
+	if t.field == 2 {
+		return t.FieldMarkString()
+	}
+
 	// Get the components of the timecode
 	components := t.Components()
 
@@ -107,7 +150,7 @@ func (t *Timecode) String() string {
 	frameFormat := fmt.Sprintf("%%0%dd", frameDigits)
 
 	// Format the timecode
-	return fmt.Sprintf(
+	str := fmt.Sprintf(
 		"%02d:%02d:%02d%s%s",
 		components.Hours,
 		components.Minutes,
@@ -115,22 +158,90 @@ func (t *Timecode) String() string {
 		sep,
 		fmt.Sprintf(frameFormat, components.Frames),
 	)
+
+	// Add the sub-frames, using as many digits as the largest sub-frame
+	if t.subframeResolution > 0 {
+		subframeDigits := len(fmt.Sprintf("%d", t.subframeResolution-1))
+		str += fmt.Sprintf(".%0*d", subframeDigits, t.subframe)
+	}
+
+	return str
 }
 
-// Equals checks if this timecode is equal to another framer
+// Equals checks if this timecode is equal to another framer. They're compared by their frame and the
+// position within it (ie. sub-frames or field), regardless of their rates, so frame 24 at 24 fps equals
+// frame 24 at 25 fps. Sub-frames at different resolutions are equal when they're at the same position.
 func (t *Timecode) Equals(other Framer) bool {
-	return other.Frame() == t.frame
+	position := RationalFromInt(other.Frame())
+	if tc, ok := other.(*Timecode); ok {
+		position = tc.framePosition()
+	}
+	return t.framePosition().Cmp(position) == 0
+}
+
+// framePosition gets the position of this timecode in frames, including any sub-frames or field
+func (t *Timecode) framePosition() Rational {
+	position := RationalFromInt(t.frame)
+	if t.subframeResolution > 0 {
+		position = position.Add(NewRational(t.subframe, int64(t.subframeResolution)))
+	}
+	if t.field == 2 {
+		position = position.Add(NewRational(1, 2))
+	}
+	return position
 }
 
-// Add adds another framer instance to this timecode
+// Add adds another framer instance to this timecode. If the other framer is a timecode with sub-frames,
+// its sub-frames are added as well, and carried into the frames. The result keeps the sub-frame resolution
+// of this timecode, so sub-frames at another resolution are rounded to the nearest sub-frame, with halves
+// rounded away from zero.
 func (t *Timecode) Add(other Framer) *Timecode {
+	tc, ok := other.(*Timecode)
+	if !ok || tc.subframeResolution == 0 {
+		return t.withFrame(t.frame + other.Frame())
+	}
+
+	// Convert the other sub-frames to the resolution of this timecode
+	resolution := t.subframeResolution
+	if resolution == 0 {
+		resolution = tc.subframeResolution
+	}
+	subframe := NewRational(tc.subframe*int64(resolution), int64(tc.subframeResolution)).Round()
+	return newSubframeTimecode(t.frame+tc.frame, t.subframe+subframe, resolution, t.rate, t.dropFrame)
+}
+
+// Sub subtracts another framer instance from this timecode. Sub-frames of another timecode are subtracted
+// the same way Add adds them.
+func (t *Timecode) Sub(other Framer) *Timecode {
+	if tc, ok := other.(*Timecode); ok && tc.subframeResolution > 0 {
+		return t.Add(newSubframeTimecode(-tc.frame, -tc.subframe, tc.subframeResolution, tc.rate, tc.dropFrame))
+	}
+	return t.withFrame(t.frame - other.Frame())
+}
+
+// ConvertRate converts this timecode to another rate, keeping the time it represents. The result is the
+// frame at the new rate that contains that time.
+func (t *Timecode) ConvertRate(rate Rate, dropFrame bool) *Timecode {
+	return FromSeconds(t.Seconds(), rate, dropFrame)
+}
+
+// withFrame creates a copy of this timecode at another frame, keeping the sub-frames and field
+func (t *Timecode) withFrame(frame int64) *Timecode {
 	return &Timecode{
-		frame:     t.frame + other.Frame(),
-		rate:      t.rate,
-		dropFrame: t.dropFrame,
+		frame:              frame,
+		rate:               t.rate,
+		dropFrame:          t.dropFrame,
+		subframe:           t.subframe,
+		subframeResolution: t.subframeResolution,
+		field:              t.field,
 	}
 }
 
+// hasSubframePosition checks if this timecode has a position within its frame
+func (t *Timecode) hasSubframePosition() bool {
+	return t.subframeResolution > 0 || t.field > 0
+}
+
 func (t *Timecode) AddFrames(other int64) *Timecode {
 	return t.Add(Frame(other))
 }
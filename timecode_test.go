@@ -172,3 +172,24 @@ func TestTimecodeSequenceDF(t *testing.T) {
 		}
 	}
 }
+
+func TestTimecode_Accessors(t *testing.T) {
+	tc := timecode.MustParse("01:00:00;00", timecode.Rate_29_97)
+	require.Equal(t, timecode.Rate_29_97, tc.Rate())
+	require.True(t, tc.DropFrame())
+	require.False(t, timecode.MustParse("01:00:00:00", timecode.Rate_24).DropFrame())
+}
+
+func TestTimecode_Sub(t *testing.T) {
+	tc := timecode.MustParse("01:00:10;00", timecode.Rate_29_97)
+	require.Equal(t, "01:00:00;00", tc.Sub(timecode.MustParse("00:00:10;00", timecode.Rate_29_97)).String())
+	require.Equal(t, "01:00:09;29", tc.Sub(timecode.Frame(1)).String())
+}
+
+func TestTimecode_ConvertRate(t *testing.T) {
+	// One hour of 23.976 timecode is 3603.6 seconds of real time
+	tc := timecode.MustParse("01:00:00:00", timecode.Rate_23_976)
+	require.Equal(t, "01:00:03:15", tc.ConvertRate(timecode.Rate_25, false).String())
+	require.Equal(t, "01:00:00:00", tc.ConvertRate(timecode.Rate_29_97, false).String())
+	require.Equal(t, "01:00:03;18", tc.ConvertRate(timecode.Rate_29_97, true).String())
+}
@@ -0,0 +1,63 @@
+package timecode
+
+import (
+	"time"
+)
+
+// FromTimeOfDay creates a time-of-day timecode from the wall clock time of t, in its location. To get
+// the time of day in another time zone, convert t with t.In first.
+//
+// Frames are counted at the exact rate since midnight. With 29.97 drop frame, a day of timecodes is
+// slightly shorter than a real day, so the last timecode of the day (23:59:59;29) is held for the
+// final fraction of a second until the midnight reset. Non-drop frame timecodes at fractional rates
+// run 0.1% slower than the wall clock, and fall behind by 86.4 seconds over the course of a day.
+func FromTimeOfDay(t time.Time, rate Rate, dropFrame bool) *Timecode {
+	return FromFrame(timeOfDayFrame(t, rate, dropFrame), rate, dropFrame)
+}
+
+// TimeOfDay gets the wall clock time at which this time-of-day timecode begins, on the calendar day
+// of date and in its location. This is the inverse of FromTimeOfDay.
+func (t *Timecode) TimeOfDay(date time.Time) time.Time {
+	offset := int64(ceilDuration(t.Seconds()))
+	year, month, day := date.Date()
+	return time.Date(
+		year, month, day, 0, 0,
+		int(offset/int64(time.Second)),
+		int(offset%int64(time.Second)),
+		date.Location(),
+	)
+}
+
+// timeOfDayFrame gets the frame index of the time of day of t, in its location
+func timeOfDayFrame(t time.Time, rate Rate, dropFrame bool) int64 {
+	frame := rate.Frames(durationSeconds(sinceMidnight(t))).Floor()
+	if perDay := framesPerDay(rate, dropFrame); frame >= perDay {
+		frame = perDay - 1
+	}
+	return frame
+}
+
+// nextTimeOfDayFrameTime gets the time after now at which the time of day frame after the given
+// one begins
+func nextTimeOfDayFrameTime(now time.Time, frame int64, rate Rate, dropFrame bool) time.Time {
+	since := sinceMidnight(now)
+	next := 24 * time.Hour
+	if frame+1 < framesPerDay(rate, dropFrame) {
+		next = ceilDuration(rate.Seconds(frame + 1))
+	}
+	return now.Add(next - since)
+}
+
+// sinceMidnight gets the wall clock time elapsed since midnight in the location of t
+func sinceMidnight(t time.Time) time.Duration {
+	hour, min, sec := t.Clock()
+	return time.Duration(hour)*time.Hour +
+		time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second +
+		time.Duration(t.Nanosecond())
+}
+
+// framesPerDay gets the number of timecodes in a 24 hour day
+func framesPerDay(rate Rate, dropFrame bool) int64 {
+	return FromComponents(Components{Hours: 24}, rate, dropFrame).Frame()
+}
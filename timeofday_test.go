@@ -0,0 +1,70 @@
+package timecode_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromTimeOfDay(t *testing.T) {
+	t.Run("integer rates match the wall clock", func(t *testing.T) {
+		tod := time.Date(2026, 1, 1, 14, 30, 15, 500_000_000, time.UTC)
+		require.Equal(t, "14:30:15:12", timecode.FromTimeOfDay(tod, timecode.Rate_25, false).String())
+		require.Equal(t, "14:30:15:30", timecode.FromTimeOfDay(tod, timecode.Rate_60, false).String())
+	})
+	t.Run("time zones", func(t *testing.T) {
+		tod := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+		newYork := time.FixedZone("EDT", -4*60*60)
+		require.Equal(t, "12:00:00:00", timecode.FromTimeOfDay(tod, timecode.Rate_24, false).String())
+		require.Equal(t, "08:00:00:00", timecode.FromTimeOfDay(tod.In(newYork), timecode.Rate_24, false).String())
+	})
+	t.Run("drop frame drifts from the wall clock", func(t *testing.T) {
+		noon := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		require.Equal(t, "12:00:00;01", timecode.FromTimeOfDay(noon, timecode.Rate_29_97, true).String())
+	})
+	t.Run("drop frame holds the last timecode until midnight", func(t *testing.T) {
+		cases := map[time.Duration]string{
+			-200 * time.Millisecond: "23:59:59;26",
+			-100 * time.Millisecond: "23:59:59;29",
+			-time.Nanosecond:        "23:59:59;29",
+			0:                       "00:00:00;00",
+		}
+		midnight := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		for offset, str := range cases {
+			require.Equal(t, str, timecode.FromTimeOfDay(midnight.Add(offset), timecode.Rate_29_97, true).String())
+		}
+	})
+	t.Run("non-drop frame at fractional rates falls behind the wall clock", func(t *testing.T) {
+		noon := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		require.Equal(t, "11:59:16:20", timecode.FromTimeOfDay(noon, timecode.Rate_23_976, false).String())
+	})
+}
+
+func TestTimecode_TimeOfDay(t *testing.T) {
+	date := time.Date(2026, 3, 8, 17, 45, 0, 0, time.UTC)
+	require.Equal(t,
+		time.Date(2026, 3, 8, 1, 0, 0, 40_000_000, time.UTC),
+		timecode.MustParse("01:00:00:01", timecode.Rate_25).TimeOfDay(date),
+	)
+
+	// The result is in the location of the date
+	tokyo := time.FixedZone("JST", 9*60*60)
+	require.Equal(t,
+		time.Date(2026, 3, 9, 1, 0, 0, 0, tokyo),
+		timecode.MustParse("01:00:00:00", timecode.Rate_25).TimeOfDay(date.In(tokyo)),
+	)
+
+	// Converting back to a timecode gives the same timecode
+	cases := []string{
+		"00:00:00;00",
+		"00:01:00;02",
+		"12:00:00;01",
+		"23:59:59;29",
+	}
+	for _, str := range cases {
+		tc := timecode.MustParse(str, timecode.Rate_29_97)
+		require.Equal(t, str, timecode.FromTimeOfDay(tc.TimeOfDay(date), timecode.Rate_29_97, true).String())
+	}
+}
@@ -0,0 +1,193 @@
+package timecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// TmcdFlags are the flags of a QuickTime timecode sample entry
+type TmcdFlags uint32
+
+const (
+	// TmcdFlag_DropFrame indicates that the timecode is drop frame
+	TmcdFlag_DropFrame TmcdFlags = 0x1
+	// TmcdFlag_24HourMax indicates that the timecode wraps around at 24 hours
+	TmcdFlag_24HourMax TmcdFlags = 0x2
+	// TmcdFlag_NegativeTimesOK indicates that the timecode can be negative
+	TmcdFlag_NegativeTimesOK TmcdFlags = 0x4
+	// TmcdFlag_Counter indicates that the samples are tape counter values rather than timecodes
+	TmcdFlag_Counter TmcdFlags = 0x8
+)
+
+// tmcdEntrySize is the size of a timecode sample entry without any child atoms
+const tmcdEntrySize = 34
+
+// TmcdSampleEntry is the sample description of a QuickTime or MP4 timecode track (tmcd). The samples of
+// the track are the frame counts of the timecodes at the start of each segment of the media.
+type TmcdSampleEntry struct {
+	DataReferenceIndex uint16
+	Flags              TmcdFlags
+	Timescale          uint32
+	FrameDuration      uint32
+	NumberOfFrames     uint8
+
+	// Name is the source reference (ie. the tape name) from the optional name atom
+	Name string
+}
+
+// NewTmcdSampleEntry creates the timecode sample entry for timecodes at the given rate
+func NewTmcdSampleEntry(rate Rate, dropFrame bool) *TmcdSampleEntry {
+	entry := &TmcdSampleEntry{
+		DataReferenceIndex: 1,
+		Flags:              TmcdFlag_24HourMax,
+		Timescale:          uint32(rate.Num),
+		FrameDuration:      uint32(rate.Den),
+		NumberOfFrames:     uint8(rate.Nominal),
+	}
+	if dropFrame {
+		entry.Flags |= TmcdFlag_DropFrame
+	}
+	return entry
+}
+
+// ParseTmcdSampleEntry parses a timecode sample entry, starting from its size and 'tmcd' type
+func ParseTmcdSampleEntry(data []byte) (*TmcdSampleEntry, error) {
+	if len(data) < tmcdEntrySize {
+		return nil, errors.New("tmcd sample entry is too short")
+	}
+	size := binary.BigEndian.Uint32(data)
+	if string(data[4:8]) != "tmcd" {
+		return nil, fmt.Errorf("sample entry type is %q, not tmcd", data[4:8])
+	}
+	if size < tmcdEntrySize || int64(size) > int64(len(data)) {
+		return nil, errors.New("invalid tmcd sample entry size")
+	}
+	entry := &TmcdSampleEntry{
+		DataReferenceIndex: binary.BigEndian.Uint16(data[14:]),
+		Flags:              TmcdFlags(binary.BigEndian.Uint32(data[20:])),
+		Timescale:          binary.BigEndian.Uint32(data[24:]),
+		FrameDuration:      binary.BigEndian.Uint32(data[28:]),
+		NumberOfFrames:     data[32],
+	}
+
+	// Look for the name among the child atoms
+	for atoms := data[tmcdEntrySize:size]; len(atoms) >= 8; {
+		atomSize := binary.BigEndian.Uint32(atoms)
+		if atomSize < 8 || int64(atomSize) > int64(len(atoms)) {
+			return nil, errors.New("invalid atom in tmcd sample entry")
+		}
+		if string(atoms[4:8]) == "name" && atomSize >= 12 {
+			length := int(binary.BigEndian.Uint16(atoms[8:]))
+			if 12+length > int(atomSize) {
+				return nil, errors.New("invalid name atom in tmcd sample entry")
+			}
+			entry.Name = string(atoms[12 : 12+length])
+		}
+		atoms = atoms[atomSize:]
+	}
+	return entry, nil
+}
+
+// MarshalBinary encodes the timecode sample entry, starting from its size and 'tmcd' type. The name atom
+// is included if there is a name.
+func (e *TmcdSampleEntry) MarshalBinary() ([]byte, error) {
+	if len(e.Name) > 0xffff {
+		return nil, errors.New("tmcd name is too long")
+	}
+	data := make([]byte, tmcdEntrySize)
+	copy(data[4:], "tmcd")
+	binary.BigEndian.PutUint16(data[14:], e.DataReferenceIndex)
+	binary.BigEndian.PutUint32(data[20:], uint32(e.Flags))
+	binary.BigEndian.PutUint32(data[24:], e.Timescale)
+	binary.BigEndian.PutUint32(data[28:], e.FrameDuration)
+	data[32] = e.NumberOfFrames
+	if e.Name != "" {
+		atom := make([]byte, 12, 12+len(e.Name))
+		binary.BigEndian.PutUint32(atom, uint32(12+len(e.Name)))
+		copy(atom[4:], "name")
+		binary.BigEndian.PutUint16(atom[8:], uint16(len(e.Name)))
+		data = append(data, append(atom, e.Name...)...)
+	}
+	binary.BigEndian.PutUint32(data, uint32(len(data)))
+	return data, nil
+}
+
+// DropFrame checks if the timecodes are drop frame
+func (e *TmcdSampleEntry) DropFrame() bool {
+	return e.Flags&TmcdFlag_DropFrame != 0
+}
+
+// Rate gets the frame rate of the timecodes. Fractional rates, which are often stored approximately
+// (ie. 2997/100), are matched to the closest pull-down rate with the same number of frames.
+func (e *TmcdSampleEntry) Rate() (Rate, error) {
+	if e.Timescale == 0 || e.FrameDuration == 0 || e.NumberOfFrames == 0 {
+		return Rate{}, errors.New("tmcd sample entry has no rate")
+	}
+	rate, err := RateFromFraction(int(e.Timescale), int(e.FrameDuration))
+	if err == nil && (e.Timescale%e.FrameDuration != 0 || rate.Nominal != int(e.NumberOfFrames) ||
+		e.DropFrame() && !rate.IsDropFrameCapable()) {
+		rate, err = measuredRate(float64(e.Timescale)/float64(e.FrameDuration), 0.0005)
+	}
+	if err != nil {
+		return Rate{}, err
+	}
+	if rate.Nominal != int(e.NumberOfFrames) {
+		return Rate{}, fmt.Errorf("tmcd rate %d/%d doesn't have %d frames", e.Timescale, e.FrameDuration, e.NumberOfFrames)
+	}
+	if e.DropFrame() && !rate.IsDropFrameCapable() {
+		return Rate{}, fmt.Errorf("rate %s can't be drop frame", rate.Str)
+	}
+	return rate, nil
+}
+
+// Timecode decodes the timecode of a sample from the timecode track
+func (e *TmcdSampleEntry) Timecode(sample []byte) (*Timecode, error) {
+	if len(sample) < 4 {
+		return nil, errors.New("tmcd sample is too short")
+	}
+	if e.Flags&TmcdFlag_Counter != 0 {
+		return nil, errors.New("tmcd counter samples are not supported")
+	}
+	rate, err := e.Rate()
+	if err != nil {
+		return nil, err
+	}
+	frame := int64(binary.BigEndian.Uint32(sample))
+	if e.Flags&TmcdFlag_NegativeTimesOK != 0 {
+		frame = int64(int32(uint32(frame)))
+	}
+	if e.Flags&TmcdFlag_24HourMax != 0 && frame >= 0 {
+		frame %= framesPerDay(rate, e.DropFrame())
+	}
+	return FromFrame(frame, rate, e.DropFrame()), nil
+}
+
+// Sample encodes a timecode as a sample for the timecode track. The timecode must have the rate of the
+// sample entry.
+func (e *TmcdSampleEntry) Sample(tc *Timecode) ([]byte, error) {
+	rate, err := e.Rate()
+	if err != nil {
+		return nil, err
+	}
+	if !tc.rate.Equal(rate) {
+		return nil, fmt.Errorf("timecode rate %s (%d/%d) doesn't match tmcd rate %s (%d/%d)",
+			tc.rate.Str, tc.rate.Num, tc.rate.Den, rate.Str, rate.Num, rate.Den)
+	}
+
+	frame := tc.frame
+	minFrame, maxFrame := int64(0), int64(math.MaxUint32)
+	if e.Flags&TmcdFlag_NegativeTimesOK != 0 {
+		minFrame, maxFrame = math.MinInt32, math.MaxInt32
+	}
+	if e.Flags&TmcdFlag_24HourMax != 0 && frame >= 0 {
+		frame %= framesPerDay(rate, e.DropFrame())
+	}
+	if frame < minFrame || frame > maxFrame {
+		return nil, errors.New("timecode is out of range for a tmcd sample")
+	}
+	sample := make([]byte, 4)
+	binary.BigEndian.PutUint32(sample, uint32(frame))
+	return sample, nil
+}
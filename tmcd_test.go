@@ -0,0 +1,166 @@
+package timecode_test
+
+import (
+	"testing"
+
+	"github.com/spiretechnology/go-timecode"
+	"github.com/stretchr/testify/require"
+)
+
+// tmcdEntry is a synthetic tmcd sample entry for 29.97 drop frame, with a name atom
+var tmcdEntry = []byte{
+	// Size and type
+	0x00, 0x00, 0x00, 0x2f, 't', 'm', 'c', 'd',
+	// Reserved
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	// Data reference index
+	0x00, 0x01,
+	// Reserved
+	0x00, 0x00, 0x00, 0x00,
+	// Flags
+	0x00, 0x00, 0x00, 0x03,
+	// Timescale
+	0x00, 0x00, 0x75, 0x30,
+	// Frame duration
+	0x00, 0x00, 0x03, 0xe9,
+	// Number of frames, and reserved
+	0x1e, 0x00,
+	// Name atom
+	0x00, 0x00, 0x00, 0x0d, 'n', 'a', 'm', 'e', 0x00, 0x01, 0x00, 0x00, 'A',
+}
+
+func TestParseTmcdSampleEntry(t *testing.T) {
+	entry, err := timecode.ParseTmcdSampleEntry(tmcdEntry)
+	require.NoError(t, err)
+	require.Equal(t, &timecode.TmcdSampleEntry{
+		DataReferenceIndex: 1,
+		Flags:              timecode.TmcdFlag_DropFrame | timecode.TmcdFlag_24HourMax,
+		Timescale:          30000,
+		FrameDuration:      1001,
+		NumberOfFrames:     30,
+		Name:               "A",
+	}, entry)
+	require.True(t, entry.DropFrame())
+	rate, err := entry.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, rate)
+
+	// The sample is the frame count of 01:00:00;00
+	tc, err := entry.Timecode([]byte{0x00, 0x01, 0xa5, 0x74})
+	require.NoError(t, err)
+	require.Equal(t, "01:00:00;00", tc.String())
+
+	data, err := entry.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, tmcdEntry, data)
+}
+
+func TestParseTmcdSampleEntry_Invalid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"short":     tmcdEntry[:20],
+		"type":      append(append([]byte{}, tmcdEntry[:4]...), append([]byte("tmcx"), tmcdEntry[8:]...)...),
+		"size":      append([]byte{0x00, 0x00, 0x01, 0x00}, tmcdEntry[4:]...),
+		"atom size": append(append([]byte{}, tmcdEntry[:36]...), append([]byte{0x00, 0x00}, tmcdEntry[38:]...)...),
+	} {
+		_, err := timecode.ParseTmcdSampleEntry(data)
+		require.Error(t, err, name)
+	}
+}
+
+func TestTmcdSampleEntry_RoundTrip(t *testing.T) {
+	cases := []struct {
+		tc   string
+		rate timecode.Rate
+	}{
+		{"10:00:00:00", timecode.Rate_23_976},
+		{"10:00:00:00", timecode.Rate_24},
+		{"23:59:59:24", timecode.Rate_25},
+		{"00:10:00;02", timecode.Rate_29_97},
+		{"00:10:00:02", timecode.Rate_29_97},
+		{"01:00:00;04", timecode.Rate_59_94},
+	}
+	for _, c := range cases {
+		tc := timecode.MustParse(c.tc, c.rate)
+		data, err := timecode.NewTmcdSampleEntry(c.rate, tc.DropFrame()).MarshalBinary()
+		require.NoError(t, err)
+		entry, err := timecode.ParseTmcdSampleEntry(data)
+		require.NoError(t, err)
+		sample, err := entry.Sample(tc)
+		require.NoError(t, err)
+		decoded, err := entry.Timecode(sample)
+		require.NoError(t, err)
+		require.Equal(t, c.tc, decoded.String())
+		require.Equal(t, c.rate, decoded.Rate())
+	}
+}
+
+func TestTmcdSampleEntry_Rate(t *testing.T) {
+	// Approximate NTSC rates are matched to the pull-down rate
+	entry := &timecode.TmcdSampleEntry{Flags: timecode.TmcdFlag_DropFrame, Timescale: 2997, FrameDuration: 100, NumberOfFrames: 30}
+	rate, err := entry.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, rate)
+
+	// Non-drop frame entries are matched as well, so they can encode timecodes at the pull-down rate
+	entry = &timecode.TmcdSampleEntry{Timescale: 2997, FrameDuration: 100, NumberOfFrames: 30}
+	rate, err = entry.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_29_97, rate)
+	sample, err := entry.Sample(timecode.MustParse("01:00:00:00", timecode.Rate_29_97))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0x01, 0xa5, 0xe0}, sample)
+
+	entry = &timecode.TmcdSampleEntry{Timescale: 23976, FrameDuration: 1000, NumberOfFrames: 24}
+	rate, err = entry.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_23_976, rate)
+	_, err = entry.Sample(timecode.MustParse("01:00:00:00", timecode.Rate_24))
+	require.ErrorContains(t, err, "24 (24/1) doesn't match tmcd rate 23.976 (24000/1001)")
+
+	entry = &timecode.TmcdSampleEntry{Timescale: 600, FrameDuration: 25, NumberOfFrames: 24}
+	rate, err = entry.Rate()
+	require.NoError(t, err)
+	require.Equal(t, timecode.Rate_24, rate)
+
+	for _, entry := range []*timecode.TmcdSampleEntry{
+		{Timescale: 25, FrameDuration: 1, NumberOfFrames: 24},
+		{Flags: timecode.TmcdFlag_DropFrame, Timescale: 25, FrameDuration: 1, NumberOfFrames: 25},
+		{Timescale: 0, FrameDuration: 1, NumberOfFrames: 25},
+	} {
+		_, err := entry.Rate()
+		require.Error(t, err)
+	}
+}
+
+func TestTmcdSampleEntry_Flags(t *testing.T) {
+	entry := timecode.NewTmcdSampleEntry(timecode.Rate_25, false)
+
+	// Timecodes wrap around at 24 hours
+	tc, err := entry.Timecode([]byte{0x00, 0x20, 0xf5, 0x81})
+	require.NoError(t, err)
+	require.Equal(t, "00:00:00:01", tc.String())
+	sample, err := entry.Sample(timecode.MustParse("24:00:00:01", timecode.Rate_25))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x01}, sample)
+
+	// Negative timecodes need the flag
+	negative := timecode.FromFrame(-25, timecode.Rate_25, false)
+	_, err = entry.Sample(negative)
+	require.Error(t, err)
+	entry.Flags |= timecode.TmcdFlag_NegativeTimesOK
+	sample, err = entry.Sample(negative)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xe7}, sample)
+	tc, err = entry.Timecode(sample)
+	require.NoError(t, err)
+	require.Equal(t, int64(-25), tc.Frame())
+
+	// Mismatched rates, counters and short samples
+	_, err = entry.Sample(timecode.MustParse("01:00:00:00", timecode.Rate_24))
+	require.Error(t, err)
+	_, err = entry.Timecode([]byte{0x00, 0x01})
+	require.Error(t, err)
+	entry.Flags |= timecode.TmcdFlag_Counter
+	_, err = entry.Timecode(sample)
+	require.Error(t, err)
+}